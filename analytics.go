@@ -0,0 +1,46 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+)
+
+// Kinds of analytics events.
+const (
+	AnalyticsMount       = "mount"
+	AnalyticsInteraction = "interaction"
+	AnalyticsRoute       = "route"
+)
+
+const track = "track"
+
+// AnalyticsEvent is a structured analytics event.
+// The kind is one of mount, interaction or route.
+// The name is the element of the mounted component, the tag of the interaction or the path of the route.
+// Route events are emitted by plugins on navigation, e.g. the router.
+type AnalyticsEvent struct {
+	Kind string
+	Name string
+}
+
+// Analytics is the analytics option for components.
+// The sink receives analytics events emitted by the component and its subcomponents.
+// Interactions are tagged by the vue track attribute, e.g. v-track="signup_click".
+func Analytics(sink func(event AnalyticsEvent)) Option {
+	return func(comp *Comp) {
+		comp.analytics = sink
+	}
+}
+
+// emit emits the analytics event to the sink of the component.
+func (vm *ViewModel) emit(kind, name string) {
+	if vm.comp.analytics == nil {
+		return
+	}
+	vm.comp.analytics(AnalyticsEvent{Kind: kind, Name: name})
+}
+
+// executeAttrTrack executes the vue track attribute.
+func (tmpl *template) executeAttrTrack(node *html.Node, name string) {
+	node.Attr = append(node.Attr, html.Attribute{Key: track, Val: name})
+	tmpl.comp.callback.addEventListener(vTrack, "click")
+}
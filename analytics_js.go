@@ -0,0 +1,21 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"github.com/gowasm/go-js-dom"
+)
+
+// vTrack is the vue track event callback.
+// The closest tagged element of the target is tracked.
+func (vm *ViewModel) vTrack(event dom.Event) {
+	defer vm.report()
+	for el := event.Target(); el != nil; el = el.ParentElement() {
+		if name, ok := el.Attributes()[track]; ok {
+			vm.breadcrumb("track: %s", name)
+			vm.emit(AnalyticsInteraction, name)
+			return
+		}
+	}
+}
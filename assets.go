@@ -0,0 +1,59 @@
+package vue
+
+import (
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/net/html"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+)
+
+// Assets is the assets option for components.
+// Assets are files of the component, e.g. images and icons embedded by a generated http.FileSystem,
+// so components are self contained Go packages.
+// Elements refer to assets by path with the vue asset attribute, e.g. <img v-asset:src="icons/logo.svg">,
+// which binds the attribute to the url of the asset.
+func Assets(fsys http.FileSystem) Option {
+	return func(comp *Comp) {
+		comp.assets = fsys
+		comp.assetURLs = make(map[string]string)
+	}
+}
+
+// assetURL returns the url of the asset by path.
+// Urls are data uris which are created once per asset.
+func (comp *Comp) assetURL(name string) string {
+	if comp.assets == nil {
+		must(fmt.Errorf("component has no assets: %s", name))
+	}
+	if url, ok := comp.assetURLs[name]; ok {
+		return url
+	}
+
+	b, err := readFile(comp.assets, name)
+	must(err)
+	typ := mime.TypeByExtension(path.Ext(name))
+	if typ == "" {
+		typ = "application/octet-stream"
+	}
+	url := fmt.Sprintf("data:%s;base64,%s", typ, base64.StdEncoding.EncodeToString(b))
+	comp.assetURLs[name] = url
+	return url
+}
+
+// readFile reads the file of the file system by path.
+func readFile(fsys http.FileSystem, name string) ([]byte, error) {
+	file, err := fsys.Open(path.Join("/", name))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ioutil.ReadAll(file)
+}
+
+// executeAttrAsset executes the vue asset attribute.
+func (tmpl *template) executeAttrAsset(node *html.Node, key, name string) {
+	node.Attr = append(node.Attr, html.Attribute{Key: key, Val: tmpl.comp.assetURL(name)})
+}
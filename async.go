@@ -0,0 +1,77 @@
+package vue
+
+// async is the state of an async component.
+type async struct {
+	factory func() (*Comp, error)
+	started bool
+	comp    *Comp
+	err     error
+}
+
+// Async creates a component which resolves from the factory asynchronously.
+// The factory is called once when the component is first rendered, e.g. to fetch a remote template.
+// The loading component is rendered until resolved and the failure component if the factory fails,
+// of which the error is reported by the root component.
+func Async(factory func() (*Comp, error), options ...Option) *Comp {
+	comp := Component(options...)
+	comp.async = &async{factory: factory}
+	return comp
+}
+
+// Loading is the loading option for async components.
+// The loading component is rendered while the async component resolves.
+func Loading(loading *Comp) Option {
+	return func(comp *Comp) {
+		comp.loading = loading
+	}
+}
+
+// Failure is the failure option for async components.
+// The failure component is rendered if the async component fails to resolve.
+func Failure(failure *Comp) Option {
+	return func(comp *Comp) {
+		comp.failure = failure
+	}
+}
+
+// resolve returns the component to render in place of the component.
+// An async component starts to resolve from the factory on a goroutine,
+// then the resolved component is set by an update of the callback, which renders.
+// Components which are not async resolve to themselves.
+func (comp *Comp) resolve(callback callback) *Comp {
+	async := comp.async
+	if async == nil {
+		return comp
+	}
+
+	switch {
+	case async.comp != nil:
+		return async.comp
+	case async.err != nil:
+		return orEmpty(comp.failure)
+	case !async.started:
+		async.started = true
+		go func() {
+			resolved, err := async.factory()
+			if resolved == nil && err == nil {
+				resolved = Component()
+			}
+			callback.update(func() {
+				async.comp, async.err = resolved, err
+				invalidate()
+				if err != nil {
+					callback.reportErr(err)
+				}
+			})
+		}()
+	}
+	return orEmpty(comp.loading)
+}
+
+// orEmpty returns the component unless it is nil, then an empty component is returned.
+func orEmpty(comp *Comp) *Comp {
+	if comp == nil {
+		return Component()
+	}
+	return comp
+}
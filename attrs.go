@@ -0,0 +1,104 @@
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"strings"
+)
+
+// attrsField is the data field of the attributes which are not props.
+const attrsField = "$attrs"
+
+// InheritAttrs is the inherit attributes option for subcomponents.
+// By default, attributes on the subcomponent element which are not props, e.g. class, id and data-*,
+// are merged onto the root element of the subcomponent.
+// Attributes are available to the template as $attrs regardless of inheritance.
+func InheritAttrs(inherit bool) Option {
+	return func(sub *Comp) {
+		sub.inherit = inherit
+	}
+}
+
+// attrsData maps the attributes of a subcomponent to data.
+func (vm *ViewModel) attrsData() {
+	if !vm.comp.isSub {
+		return
+	}
+	vm.data[attrsField] = vm.comp.attrs
+}
+
+// inheritAttrs merges the attributes onto the root element of the node.
+// The class and style attributes are joined, other attributes replace those of the element.
+// Fragments of multiple root elements inherit no attributes, since the element would be ambiguous.
+func inheritAttrs(node *html.Node, attrs []html.Attribute) {
+	roots := rootElements(node)
+	if len(roots) != 1 {
+		return
+	}
+	root := roots[0]
+
+	for _, attr := range attrs {
+		i := indexAttr(root, attr.Key)
+		switch {
+		case i < 0:
+			root.Attr = append(root.Attr, attr)
+		case attr.Key == "class":
+			root.Attr[i].Val = strings.TrimSpace(root.Attr[i].Val + " " + attr.Val)
+		case attr.Key == "style":
+			root.Attr[i].Val = strings.TrimSuffix(strings.TrimSpace(root.Attr[i].Val), ";") + "; " + attr.Val
+		default:
+			root.Attr[i].Val = attr.Val
+		}
+	}
+}
+
+// indexAttr returns the index of the attribute of the node by key.
+// Returns -1 for unknown attributes.
+func indexAttr(node *html.Node, key string) int {
+	for i, attr := range node.Attr {
+		if attr.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// keyFragment keys the root elements of the fragment by the key of the subcomponent element,
+// suffixed by their index after the first, so each element of the fragment is moved with its siblings.
+// Nodes of a single root element are unchanged.
+func keyFragment(node *html.Node, attrs []html.Attribute) {
+	var fragmentKey string
+	for _, attr := range attrs {
+		if attr.Key == key {
+			fragmentKey = attr.Val
+		}
+	}
+	roots := rootElements(node)
+	if len(roots) < 2 || fragmentKey == "" {
+		return
+	}
+	for n, root := range roots {
+		val := fragmentKey
+		if n > 0 {
+			val = fmt.Sprintf("%s:%d", fragmentKey, n)
+		}
+		if j := indexAttr(root, key); j >= 0 {
+			deleteAttr(root, j)
+		}
+		root.Attr = append(root.Attr, html.Attribute{Key: key, Val: val})
+	}
+}
+
+// rootElements returns the element children of the node.
+func rootElements(node *html.Node) []*html.Node {
+	var roots []*html.Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode {
+			roots = append(roots, child)
+		}
+	}
+	return roots
+}
+
+// key is the attribute which identifies elements among their siblings across renders.
+const key = "key"
@@ -0,0 +1,38 @@
+package vue
+
+// Data fields of the battery status.
+const (
+	batteryLevelField    = "BatteryLevel"
+	batteryChargingField = "BatteryCharging"
+)
+
+// battery is the battery status of a component.
+type battery struct {
+	level    float64
+	charging bool
+}
+
+// Battery is the battery status option for root components.
+// The battery level from 0 to 1 and the charging state are mapped to the BatteryLevel and BatteryCharging
+// data fields unless data has the same fields.
+// The component renders when the battery status changes.
+// Browsers without the battery status api keep a full and charging battery.
+func Battery() Option {
+	return func(comp *Comp) {
+		comp.battery = &battery{level: 1, charging: true}
+	}
+}
+
+// batteryData maps the battery status to data.
+func (vm *ViewModel) batteryData() {
+	battery := vm.comp.battery
+	if battery == nil {
+		return
+	}
+	if _, ok := vm.data[batteryLevelField]; !ok {
+		vm.data[batteryLevelField] = battery.level
+	}
+	if _, ok := vm.data[batteryChargingField]; !ok {
+		vm.data[batteryChargingField] = battery.charging
+	}
+}
@@ -0,0 +1,36 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"syscall/js"
+)
+
+// watchBattery watches the battery status of the device.
+func (vm *ViewModel) watchBattery() {
+	battery := vm.comp.battery
+	if battery == nil || vm.comp.isSub {
+		return
+	}
+	navigator := js.Global().Get("navigator")
+	if navigator.Get("getBattery") == js.Undefined() {
+		return
+	}
+
+	go func() {
+		manager, err := await(navigator.Call("getBattery"))
+		if err != nil {
+			return
+		}
+		update := func(js.Value) {
+			defer vm.report()
+			battery.level = manager.Get("level").Float()
+			battery.charging = manager.Get("charging").Bool()
+			vm.render()
+		}
+		listen(manager, "levelchange", update)
+		listen(manager, "chargingchange", update)
+		update(js.Undefined())
+	}()
+}
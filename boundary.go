@@ -0,0 +1,155 @@
+package vue
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang.org/x/net/html"
+)
+
+// errorBoundary is the element which isolates errors from rendering or handling events of its children,
+// which are replaced by the children of its fallback slot once an error occurs:
+//
+//	<error-boundary name="chart">
+//		<sales-chart :data="Sales"></sales-chart>
+//		<p slot="fallback">The chart failed to load: {{ Err }}</p>
+//	</error-boundary>
+//
+// The error message is rendered by the Err field of the fallback, while the rest of the page renders as usual.
+// Errors are still passed to the global error handler.
+// Boundaries are identified by their name, otherwise by their path in the template,
+// e.g. to distinguish the boundaries of a for loop by name.
+// Errored boundaries render their children again once the data or props of their component change,
+// or once reset by ResetBoundary, e.g. by a retry button of the fallback.
+const errorBoundary = "error-boundary"
+
+// boundary is the state of an errored error boundary.
+// The snapshot is the data and props of the component when the fallback was first rendered,
+// which is empty until then, e.g. for errors of events.
+type boundary struct {
+	err      error
+	snapshot string
+	rendered bool
+}
+
+// boundaryAttr is the attribute of the elements rendered by an error boundary to its identity.
+const boundaryAttr = "data-error-boundary"
+
+// fallbackSlot is the name of the slot rendered by error boundaries once an error occurs.
+const fallbackSlot = "fallback"
+
+// executeErrorBoundary executes the children of the error boundary in place of the element,
+// otherwise the fallback slot when the boundary errored, now or previously.
+func (tmpl *template) executeErrorBoundary(node *html.Node, data map[string]interface{}) *html.Node {
+	id := nodePath(node)
+	for _, attr := range node.Attr {
+		if attr.Key == "name" {
+			id = attr.Val
+		}
+	}
+
+	var fallback []*html.Node
+	for _, child := range children(node) {
+		for i, attr := range child.Attr {
+			if attr.Key == "slot" && attr.Val == fallbackSlot {
+				deleteAttr(child, i)
+				node.RemoveChild(child)
+				fallback = append(fallback, child)
+				break
+			}
+		}
+	}
+
+	vm := tmpl.comp.root().vm
+	snapshot := tmpl.comp.boundarySnapshot()
+	b, ok := vm.boundaries[id]
+	if ok && b.rendered && b.snapshot != snapshot {
+		delete(vm.boundaries, id)
+		ok = false
+	}
+	err := b.err
+	if !ok {
+		err = tmpl.executeBoundary(node, data)
+	}
+	if err != nil {
+		vm.catchBoundary(id, err)
+		vm.boundaries[id] = boundary{err: vm.boundaries[id].err, snapshot: snapshot, rendered: true}
+		for _, child := range children(node) {
+			node.RemoveChild(child)
+		}
+		scope := make(map[string]interface{}, len(data)+1)
+		for key, value := range data {
+			scope[key] = value
+		}
+		scope["Err"] = err.Error()
+		for _, child := range fallback {
+			node.AppendChild(child)
+			tmpl.executeElement(child, scope)
+		}
+		tmpl.executeText(node, scope)
+	}
+
+	for _, child := range children(node) {
+		if child.Type == html.ElementNode {
+			child.Attr = append(child.Attr, html.Attribute{Key: boundaryAttr, Val: id})
+		}
+		node.RemoveChild(child)
+		node.Parent.InsertBefore(child, node)
+	}
+	next := node.NextSibling
+	node.Parent.RemoveChild(node)
+	return next
+}
+
+// executeBoundary executes the children of the error boundary, which recovers the error of the execution.
+func (tmpl *template) executeBoundary(node *html.Node, data map[string]interface{}) (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		if err, _ = r.(error); err == nil {
+			err = fmt.Errorf("%v", r)
+		}
+		if sub, ok := err.(*subErr); ok {
+			err = sub.err
+		}
+	}()
+	for child := node.FirstChild; child != nil; {
+		child = tmpl.executeElement(child, data)
+	}
+	tmpl.executeText(node, data)
+	return nil
+}
+
+// catchBoundary marks the error boundary as errored, then passes the error to the global error handler once.
+func (vm *ViewModel) catchBoundary(id string, err error) {
+	if _, ok := vm.boundaries[id]; ok {
+		return
+	}
+	if vm.boundaries == nil {
+		vm.boundaries = make(map[string]boundary)
+	}
+	vm.boundaries[id] = boundary{err: err}
+	vm.breadcrumb("error boundary: %s", id)
+	if errorHandler != nil {
+		errorHandler(err, vm)
+	}
+}
+
+// boundarySnapshot returns the snapshot of the data and props of the component,
+// which is empty when they can not be snapshot, so the boundary is only reset by ResetBoundary.
+func (comp *Comp) boundarySnapshot() string {
+	b, err := json.Marshal([]interface{}{comp.data, comp.mixins, comp.props})
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// ResetBoundary resets the errored error boundary by name, which renders its children again,
+// e.g. to retry loading after a failure.
+func (vm *ViewModel) ResetBoundary(name string) {
+	root := vm.comp.root().vm
+	delete(root.boundaries, name)
+	root.render()
+}
@@ -0,0 +1,30 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"fmt"
+	"github.com/gowasm/go-js-dom"
+	"syscall/js"
+)
+
+// recoverBoundary recovers the panic of handling the event of an element within an error boundary,
+// which renders its fallback instead. Errors outside of boundaries panic again.
+// RecoverBoundary must be deferred directly to recover.
+func (vm *ViewModel) recoverBoundary(target dom.Element) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	boundary := target.Underlying().Call("closest", "["+boundaryAttr+"]")
+	if boundary == js.Null() {
+		panic(r)
+	}
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("%v", r)
+	}
+	vm.catchBoundary(boundary.Call("getAttribute", boundaryAttr).String(), err)
+	vm.render()
+}
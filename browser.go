@@ -0,0 +1,64 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"github.com/gowasm/go-js-dom"
+	"syscall/js"
+)
+
+// Components render to the dom of the browser by wasm, otherwise only on the server, e.g. by RenderToString.
+// The state and hooks of view models which interact with the browser are declared per platform.
+
+// element is the element of the el option.
+type element = dom.Element
+
+// browser is the state of the view model in the browser.
+type browser struct {
+	vnode     *vnode
+	callbacks map[string]listener
+	teleports map[string]*vnode
+	unlisten  []func()
+	hydrating bool
+	event     *Event
+	title     *string
+	adopted   []dom.Node
+}
+
+// Value is a javascript value, e.g. of refs and events.
+type Value = js.Value
+
+// watch creates the virtual dom of the root element, restores state,
+// then watches the browser on behalf of the view model.
+func (vm *ViewModel) watch() {
+	comp := vm.comp
+	vm.vnode = newNode(comp.el)
+	if vm.vnode != nil {
+		vm.vnode.share(&patches{})
+	}
+	vm.callbacks = make(map[string]listener, 0)
+	// The state of the server is restored before persisted data and the query fields, which take precedence.
+	if comp.hydrate && !comp.isSub {
+		vm.hydrateState()
+		vm.hydrating = vm.vnode != nil
+	}
+	// Persisted data is restored before the url binds the query fields, which take precedence.
+	if comp.persistence != nil && !comp.isSub {
+		vm.restorePersisted()
+	}
+	vm.watchIdle()
+	vm.watchVisibility()
+	vm.watchFullscreen()
+	vm.watchBattery()
+	vm.watchWakeLock()
+	vm.watchRecognition()
+	vm.watchSerial()
+	vm.watchGamepads()
+	vm.watchQuery()
+}
+
+// querySelector returns the first element of the document which matches the selector.
+func querySelector(selector string) element {
+	return document.QuerySelector(selector)
+}
@@ -0,0 +1,82 @@
+// Package bus is an event bus, e.g. for sibling components to communicate without props.
+// Listeners of components run on the render loop, then the component renders,
+// and they are removed once the component is unmounted:
+//
+//	var saved = bus.New()
+//
+//	vue.Mounted(func(context vue.Context) {
+//		saved.On(context, func(event interface{}) {
+//			context.Data().(*Data).Last = event.(Todo).Text
+//		})
+//	})
+//
+//	saved.Emit(todo)
+package bus
+
+import (
+	"github.com/norunners/vue"
+	"sync"
+)
+
+// Bus emits events to its listeners.
+type Bus struct {
+	mu        sync.Mutex
+	listeners map[int]func(interface{})
+	next      int
+}
+
+// New creates a new event bus.
+func New() *Bus {
+	return &Bus{listeners: make(map[int]func(interface{}))}
+}
+
+// On adds the listener on behalf of the component of the context, then returns its id to remove it by Off.
+// The listener runs on the render loop of the component, then the component renders.
+// The listener is removed once the component is unmounted.
+// Listeners without a context, e.g. of services, run on the goroutine which emits.
+func (b *Bus) On(context vue.Context, listener func(event interface{})) int {
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	if context == nil {
+		b.listeners[id] = listener
+	} else {
+		b.listeners[id] = func(event interface{}) {
+			context.Update(func(interface{}) {
+				listener(event)
+			})
+		}
+	}
+	b.mu.Unlock()
+
+	if context != nil {
+		context.OnUnmount(func() {
+			b.Off(id)
+		})
+	}
+	return id
+}
+
+// Off removes the listener by id.
+func (b *Bus) Off(id int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.listeners, id)
+}
+
+// Emit emits the event to the listeners in the order they were added.
+// Emit is safe to call from any goroutine.
+func (b *Bus) Emit(event interface{}) {
+	b.mu.Lock()
+	listeners := make([]func(interface{}), 0, len(b.listeners))
+	for id := 0; id < b.next; id++ {
+		if listener, ok := b.listeners[id]; ok {
+			listeners = append(listeners, listener)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
@@ -0,0 +1,96 @@
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Templates are compiled once by their hash, then renders clone the compiled nodes.
+// Compiled templates are persisted to local storage when enabled,
+// so reloads skip parsing large templates.
+
+// templates are the compiled templates by hash.
+var templates = make(map[string]*html.Node)
+
+// templatesMu locks the compiled templates.
+var templatesMu sync.Mutex
+
+// persist determines if compiled templates are persisted to local storage.
+var persist bool
+
+// Keys of compiled templates in local storage are prefixed by the version of the persisted representation,
+// which is incremented when the representation changes, so templates of other versions are never loaded.
+const (
+	cachePrefix  = "vue:tmpl:"
+	cacheVersion = "v1:"
+)
+
+// PersistTemplates persists compiled templates to local storage, keyed by the hash of templates.
+// Persisting is intended for development and optionally production to improve startup of large apps.
+// Templates of other versions are evicted, and all templates are evicted once local storage is full.
+func PersistTemplates() {
+	persist = true
+}
+
+// compiled is the persisted representation of a compiled html node.
+type compiled struct {
+	Type      html.NodeType    `json:"t"`
+	Data      string           `json:"d"`
+	Namespace string           `json:"n,omitempty"`
+	Attr      []html.Attribute `json:"a,omitempty"`
+	Children  []compiled       `json:"c,omitempty"`
+}
+
+// compileTemplate returns a clone of the compiled template.
+// The node returned is a placeholder, not to be rendered.
+// Templates compile under the lock, e.g. by concurrent renders on the server.
+func compileTemplate(tmpl string) *html.Node {
+	templatesMu.Lock()
+	defer templatesMu.Unlock()
+	key := templateHash(tmpl)
+	node, ok := templates[key]
+	if !ok {
+		node, ok = loadTemplate(key)
+	}
+	if !ok {
+		start := time.Now()
+		node = parseNode(tmpl)
+		storeTemplate(key, node)
+		atomic.AddInt64(&compileTime, int64(time.Since(start)))
+	}
+	templates[key] = node
+	return cloneNode(node)
+}
+
+// templateHash returns the hash of the template.
+func templateHash(tmpl string) string {
+	h := fnv.New64a()
+	h.Write([]byte(tmpl))
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+// compile recursively creates the persisted representation of the node.
+func compile(node *html.Node) compiled {
+	c := compiled{Type: node.Type, Data: node.Data, Namespace: node.Namespace, Attr: node.Attr}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		c.Children = append(c.Children, compile(child))
+	}
+	return c
+}
+
+// node recursively creates the html node of the persisted representation.
+func (c compiled) node() *html.Node {
+	node := &html.Node{Type: c.Type, Data: c.Data, Namespace: c.Namespace, Attr: c.Attr}
+	if c.Type == html.ElementNode {
+		node.DataAtom = atom.Lookup([]byte(c.Data))
+	}
+	for _, child := range c.Children {
+		node.AppendChild(child.node())
+	}
+	return node
+}
@@ -0,0 +1,90 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang.org/x/net/html"
+	"strings"
+	"syscall/js"
+)
+
+// evicted determines if the templates of other versions were evicted from local storage.
+var evicted bool
+
+// localStorage returns the local storage of the browser.
+// Returns false unless persisting is enabled and local storage is available.
+func localStorage() (js.Value, bool) {
+	if !persist {
+		return js.Undefined(), false
+	}
+	storage := js.Global().Get("localStorage")
+	if storage == js.Undefined() || storage == js.Null() {
+		return js.Undefined(), false
+	}
+	return storage, true
+}
+
+// loadTemplate loads the compiled template from local storage.
+func loadTemplate(key string) (*html.Node, bool) {
+	storage, ok := localStorage()
+	if !ok {
+		return nil, false
+	}
+	item := storage.Call("getItem", cachePrefix+cacheVersion+key)
+	if item == js.Null() {
+		return nil, false
+	}
+	var c compiled
+	if err := json.Unmarshal([]byte(item.String()), &c); err != nil {
+		return nil, false
+	}
+	return c.node(), true
+}
+
+// storeTemplate stores the compiled template to local storage.
+// Templates of other versions are evicted first. Once local storage is full, all templates are evicted,
+// then storing is retried once, otherwise the template is not persisted.
+func storeTemplate(key string, node *html.Node) {
+	storage, ok := localStorage()
+	if !ok {
+		return
+	}
+	if !evicted {
+		evicted = true
+		evictTemplates(storage, false)
+	}
+	b, err := json.Marshal(compile(node))
+	if err != nil {
+		cacheWarn(err)
+		return
+	}
+	if err := setItem(storage, cachePrefix+cacheVersion+key, string(b)); err != nil {
+		evictTemplates(storage, true)
+		if err := setItem(storage, cachePrefix+cacheVersion+key, string(b)); err != nil {
+			cacheWarn(err)
+		}
+	}
+}
+
+// evictTemplates removes the compiled templates of other versions from local storage,
+// or all compiled templates.
+func evictTemplates(storage js.Value, all bool) {
+	var keys []string
+	for i := 0; i < storage.Get("length").Int(); i++ {
+		key := storage.Call("key", i).String()
+		if strings.HasPrefix(key, cachePrefix) && (all || !strings.HasPrefix(key, cachePrefix+cacheVersion)) {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range keys {
+		storage.Call("removeItem", key)
+	}
+}
+
+// cacheWarn logs the error of persisting compiled templates as a warning to the console.
+func cacheWarn(err error) {
+	js.Global().Get("console").Call("warn", fmt.Sprintf("compiled template is not persisted: %v", err))
+}
@@ -0,0 +1,97 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"fmt"
+	"github.com/gowasm/go-js-dom"
+)
+
+// addEventListener adds the callback of the vue attribute to the element and the targets of teleports
+// as an event listener unless the type was previously added for the attribute.
+func (vm *ViewModel) addEventListener(attr, typ string) {
+	key := attr + ":" + typ
+	_, ok := vm.callbacks[key]
+	if ok {
+		return
+	}
+	var cb func(dom.Event)
+	switch attr {
+	case vModel:
+		cb = vm.vModel
+	case vOn:
+		cb = vm.vOn
+	case vTrack:
+		cb = vm.vTrack
+	case vFullscreen:
+		cb = vm.vFullscreen
+	default:
+		must(fmt.Errorf("unknown event attribute: %s", attr))
+	}
+	vm.listen(vm.comp.el, typ, cb)
+	vm.addTeleportListener(typ, cb)
+	vm.callbacks[key] = listener{typ: typ, cb: cb}
+}
+
+// vModel is the vue model event callback.
+func (vm *ViewModel) vModel(event dom.Event) {
+	defer vm.report()
+	typ := event.Type()
+	key, ok := event.Target().Attributes()[typ]
+	if !ok {
+		must(fmt.Errorf("unknown event type: %s", typ))
+	}
+
+	// Editable elements bind the sanitized html instead of the value.
+	target := event.Target()
+	attrs := target.Attributes()
+	var value interface{}
+	if isContentEditable(attrs) {
+		value = sanitize(target.InnerHTML())
+	} else {
+		value = target.Underlying().Get("value").String()
+	}
+
+	// Checkboxes bind the checked state.
+	// Formatted models parse the value in the locale of the user.
+	// Invalid values are marked and leave the field unchanged.
+	format, ok := attrs[modelFormat]
+	switch {
+	case !ok:
+	case format == formatChecked:
+		value = target.Underlying().Get("checked").Bool()
+	default:
+		number, err := parseLocale(value.(string), format)
+		if err != nil {
+			target.SetAttribute("aria-invalid", "true")
+			return
+		}
+		target.RemoveAttribute("aria-invalid")
+		value = number
+	}
+
+	comp, field := vm.model(key)
+	vm.breadcrumb("event: %s %s", typ, field)
+	comp.setModel(field, value)
+	vm.render()
+}
+
+// vOn is the vue on event callback.
+// Errors of elements within error boundaries render the fallback of the boundary.
+func (vm *ViewModel) vOn(event dom.Event) {
+	defer vm.report()
+	defer vm.recoverBoundary(event.Target())
+	typ := event.Type()
+	method, ok := event.Target().Attributes()[typ]
+	if !ok {
+		must(fmt.Errorf("unknown event type: %s", typ))
+	}
+
+	vm.breadcrumb("event: %s %s", typ, method)
+	vm.event = &Event{event: event}
+	defer func() {
+		vm.event = nil
+	}()
+	vm.Call(method)
+}
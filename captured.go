@@ -0,0 +1,80 @@
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+)
+
+// errorHandler is the global error handler.
+var errorHandler func(error, Context)
+
+// ErrorHandler sets the global error handler.
+// Errors from renders and event handlers of all components are forwarded to the handler,
+// with the context of the component where the error occurred, instead of panicking.
+func ErrorHandler(handler func(err error, context Context)) {
+	errorHandler = handler
+}
+
+// ErrorCaptured is the error captured hook option for components.
+// Errors from executing descendant components are passed to the hook,
+// with the context of the component where the error occurred.
+// The hook returns true to capture the error, which renders the child empty,
+// e.g. an error boundary which logs the error and renders the rest of the page.
+// Uncaptured errors propagate to the hooks of further ancestors then to the error handlers.
+func ErrorCaptured(hook func(err error, context Context) bool) Option {
+	return func(comp *Comp) {
+		comp.captured = hook
+	}
+}
+
+// subErr is an error which occurred from executing a subcomponent.
+type subErr struct {
+	err     error
+	context Context
+}
+
+// Error returns the underlying error message.
+func (err *subErr) Error() string {
+	return err.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *subErr) Unwrap() error {
+	return err.err
+}
+
+// executeCaptured executes the subcomponent, errors of which may be captured by the parent.
+// Captured errors render the subcomponent empty, otherwise the error panics again to the ancestors.
+func (sub *Comp) executeCaptured() (node *html.Node) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		err, ok := r.(*subErr)
+		if !ok {
+			cause, ok := r.(error)
+			if !ok {
+				cause = fmt.Errorf("%v", r)
+			}
+			err = &subErr{err: cause, context: sub.context()}
+		}
+		if hook := sub.parent.captured; hook == nil || !hook(err.err, err.context) {
+			panic(err)
+		}
+		node = &html.Node{Type: html.ElementNode}
+	}()
+	return sub.execute()
+}
+
+// context returns the context of the closest component with a view model,
+// since functional components have none.
+func (comp *Comp) context() Context {
+	for ; comp != nil; comp = comp.parent {
+		if comp.vm != nil {
+			return comp.vm
+		}
+	}
+	return nil
+}
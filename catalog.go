@@ -0,0 +1,69 @@
+package vue
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Catalog writes a markdown catalog of the globally registered components,
+// e.g. to document the contracts of a component library.
+// Components are listed by element with their props, methods, computed and subcomponents.
+func Catalog(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("# Components\n")
+	for _, element := range sortedKeys(registry) {
+		registry[element].catalog(&b, element)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// catalog writes the markdown entry of the component by element.
+func (comp *Comp) catalog(b *strings.Builder, element string) {
+	fmt.Fprintf(b, "\n## <%s>\n", element)
+	if comp.functional != nil {
+		b.WriteString("\nFunctional component.\n")
+	}
+
+	if len(comp.props) > 0 {
+		b.WriteString("\n### Props\n\n| Name | Type | Default | Required |\n| --- | --- | --- | --- |\n")
+		for _, prop := range sortedKeys(comp.props) {
+			typ, def, required := "any", "", false
+			if propType, ok := comp.propTypes[prop]; ok {
+				typ = propType.typ.String()
+				def = fmt.Sprintf("`%v`", propType.def)
+				required = propType.required
+			}
+			fmt.Fprintf(b, "| %s | %s | %s | %t |\n", prop, typ, def, required)
+		}
+	}
+
+	catalogList(b, "Methods", sortedKeys(comp.methods))
+	catalogList(b, "Computed", sortedKeys(comp.computed))
+	catalogList(b, "Subcomponents", sortedKeys(comp.subs))
+}
+
+// catalogList writes the markdown section of names unless empty.
+func catalogList(b *strings.Builder, title string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n### %s\n\n", title)
+	for _, name := range names {
+		fmt.Fprintf(b, "- %s\n", name)
+	}
+}
+
+// sortedKeys returns the string keys of the map in sorted order.
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.String())
+	}
+	sort.Strings(names)
+	return names
+}
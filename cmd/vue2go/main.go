@@ -0,0 +1,49 @@
+// Command vue2go converts Vue single-file components into Go components.
+//
+// Usage:
+//
+//	vue2go [-pkg name] file.vue...
+//
+// Each file.vue is converted into file_vue.go beside it, e.g. todo-item.vue into todo-item_vue.go
+// with the TodoItem constructor to register by vue.Sub("todo-item", TodoItem()).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/norunners/vue/sfc"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	pkg := flag.String("pkg", "main", "package name of the generated files")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: vue2go [-pkg name] file.vue...")
+		os.Exit(2)
+	}
+
+	for _, path := range flag.Args() {
+		if err := convert(*pkg, path); err != nil {
+			fmt.Fprintf(os.Stderr, "vue2go: %s: %v\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// convert converts the single-file component at the path into a Go file beside it.
+func convert(pkg, path string) error {
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSuffix(filepath.Base(path), ".vue")
+	out, err := sfc.Convert(pkg, name, src)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(strings.TrimSuffix(path, ".vue")+"_vue.go", out, 0644)
+}
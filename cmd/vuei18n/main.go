@@ -0,0 +1,79 @@
+// Command vuei18n extracts the keys of translations from templates and Go source,
+// then updates the json catalogs of messages by locale with the missing keys flagged.
+//
+// Usage:
+//
+//	vuei18n [-dir path] [-check] catalog.json...
+//
+// Catalogs are json objects of messages by key, e.g. locales/de.json, which are created when missing.
+// Missing keys are added with the message "TODO: key" to translate. Missing and unused keys are reported.
+// The check flag fails when keys are missing, e.g. in continuous integration.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/norunners/vue/extract"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory of the templates and Go source")
+	check := flag.Bool("check", false, "fail when keys are missing")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: vuei18n [-dir path] [-check] catalog.json...")
+		os.Exit(2)
+	}
+
+	keys, err := extract.Dir(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "vuei18n: %v\n", err)
+		os.Exit(1)
+	}
+	failed := false
+	for _, path := range flag.Args() {
+		missing, err := update(path, keys)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "vuei18n: %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		failed = failed || len(missing) > 0
+	}
+	if *check && failed {
+		os.Exit(1)
+	}
+}
+
+// update updates the catalog at the path with the keys, then reports its missing and unused keys.
+// Returns the missing keys.
+func update(path string, keys []string) ([]string, error) {
+	catalog := make(map[string]string)
+	b, err := ioutil.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(b, &catalog); err != nil {
+			return nil, err
+		}
+	}
+
+	missing, unused := extract.Update(catalog, keys)
+	if len(missing) > 0 {
+		fmt.Printf("%s: %d missing: %s\n", path, len(missing), strings.Join(missing, ", "))
+	}
+	if len(unused) > 0 {
+		fmt.Printf("%s: %d unused: %s\n", path, len(unused), strings.Join(unused, ", "))
+	}
+
+	b, err = json.MarshalIndent(catalog, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return missing, ioutil.WriteFile(path, append(b, '\n'), 0644)
+}
@@ -0,0 +1,183 @@
+// Package collab syncs fields of component data across clients, e.g. for collaborative editors.
+// Edits are broadcast by a transport, e.g. a WebSocket relay, and concurrent edits are resolved by an adapter,
+// e.g. a CRDT or operational transformation. Clients joining later request the state of the others,
+// which adapters send by their snapshot. Invalid messages of peers are warned in the console and dropped.
+package collab
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/norunners/vue"
+	"reflect"
+	"strings"
+)
+
+// Transport broadcasts messages between clients.
+type Transport interface {
+	Send(msg []byte) error
+	OnMessage(fn func(msg []byte))
+}
+
+// Adapter resolves concurrent edits of fields.
+type Adapter interface {
+	// Local records the local edit of the field and returns the message to broadcast.
+	Local(field string, value json.RawMessage) ([]byte, error)
+	// Remote merges the remote message and returns the resolved values of fields to apply.
+	Remote(msg []byte) (map[string]json.RawMessage, error)
+}
+
+// Snapshotter is an adapter which snapshots its state for clients joining later,
+// e.g. the messages of the latest edits of fields, which the clients merge by Remote.
+type Snapshotter interface {
+	Snapshot() ([][]byte, error)
+}
+
+// message is the envelope of messages between clients, which is either a join or an edit of the adapter.
+type message struct {
+	Join bool            `json:"join,omitempty"`
+	Edit json.RawMessage `json:"edit,omitempty"`
+}
+
+// Sync syncs the designated fields of the data of a view model.
+type Sync struct {
+	vm        *vue.ViewModel
+	adapter   Adapter
+	transport Transport
+	fields    map[string]struct{}
+}
+
+// New creates a new sync of the fields of the view model, which are named like in templates, e.g. Doc.Title.
+// Remote edits are applied to the data by updates of the view model.
+// The sync joins the other clients, which send their snapshot.
+func New(vm *vue.ViewModel, adapter Adapter, transport Transport, fields ...string) *Sync {
+	s := &Sync{vm: vm, adapter: adapter, transport: transport, fields: make(map[string]struct{}, len(fields))}
+	for _, field := range fields {
+		s.fields[field] = struct{}{}
+	}
+	transport.OnMessage(s.receive)
+	if err := s.send(message{Join: true}); err != nil {
+		warn(err)
+	}
+	return s
+}
+
+// Set sets the synced field by the context of a method then broadcasts the edit.
+func (s *Sync) Set(context vue.Context, field string, value interface{}) error {
+	if _, ok := s.fields[field]; !ok {
+		return fmt.Errorf("field is not synced: %s", field)
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	context.Set(field, value)
+
+	msg, err := s.adapter.Local(field, raw)
+	if err != nil {
+		return err
+	}
+	return s.send(message{Edit: msg})
+}
+
+// send sends the message to the other clients.
+func (s *Sync) send(msg message) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.transport.Send(b)
+}
+
+// receive answers joins by the snapshot of the adapter,
+// otherwise merges the remote edit then applies the resolved fields to the data.
+// Invalid messages are warned and dropped.
+func (s *Sync) receive(b []byte) {
+	var msg message
+	if err := json.Unmarshal(b, &msg); err != nil {
+		warn(fmt.Errorf("invalid message: %v", err))
+		return
+	}
+	if msg.Join {
+		s.snapshot()
+		return
+	}
+	s.vm.Update(func(data interface{}) {
+		values, err := s.adapter.Remote(msg.Edit)
+		if err != nil {
+			warn(fmt.Errorf("invalid edit: %v", err))
+			return
+		}
+		for field, raw := range values {
+			if _, ok := s.fields[field]; !ok {
+				continue
+			}
+			if err := setField(data, field, raw); err != nil {
+				warn(fmt.Errorf("invalid edit of field: %s: %v", field, err))
+			}
+		}
+	})
+}
+
+// snapshot sends the snapshot of the adapter to the joined client, unless the adapter does not snapshot.
+func (s *Sync) snapshot() {
+	snapshotter, ok := s.adapter.(Snapshotter)
+	if !ok {
+		return
+	}
+	edits, err := snapshotter.Snapshot()
+	if err != nil {
+		warn(err)
+		return
+	}
+	for _, edit := range edits {
+		if err := s.send(message{Edit: edit}); err != nil {
+			warn(err)
+			return
+		}
+	}
+}
+
+// setField decodes the json value into the data field of the dotted path, which is named by vue tags.
+func setField(data interface{}, field string, raw json.RawMessage) error {
+	value := reflect.Indirect(reflect.ValueOf(data))
+	for _, name := range strings.Split(field, ".") {
+		if value.Kind() != reflect.Struct {
+			return fmt.Errorf("unknown data field: %s", field)
+		}
+		next, ok := structField(value, name)
+		if !ok {
+			return fmt.Errorf("unknown data field: %s", field)
+		}
+		if next.Kind() == reflect.Ptr {
+			if next.IsNil() {
+				next.Set(reflect.New(next.Type().Elem()))
+			}
+			next = next.Elem()
+		}
+		value = next
+	}
+	if !value.CanAddr() {
+		return fmt.Errorf("unknown data field: %s", field)
+	}
+	return json.Unmarshal(raw, value.Addr().Interface())
+}
+
+// structField returns the exported field of the struct named by the vue tag.
+func structField(value reflect.Value, name string) (reflect.Value, bool) {
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.PkgPath == "" && fieldName(field) == name {
+			return value.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// fieldName returns the name of the struct field by the vue tag.
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("vue"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,23 @@
+//go:build js && wasm
+// +build js,wasm
+
+package collab
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// listen adds the function as an event listener of the target.
+// The function receives the event.
+func listen(target js.Value, typ string, fn func(event js.Value)) {
+	cb := js.NewCallback(func(args []js.Value) {
+		fn(args[0])
+	})
+	target.Call("addEventListener", typ, cb)
+}
+
+// warn logs the error as a warning to the console.
+func warn(err error) {
+	js.Global().Get("console").Call("warn", fmt.Sprintf("collab: %v", err))
+}
@@ -0,0 +1,81 @@
+package collab
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// lww is a last-writer-wins map, a state based CRDT.
+// Each field converges to the edit with the greatest Lamport clock, ordered by client on ties.
+type lww struct {
+	mu      sync.Mutex
+	client  string
+	clock   uint64
+	entries map[string]entry
+}
+
+// entry is an edit of a field.
+type entry struct {
+	Field  string          `json:"field"`
+	Value  json.RawMessage `json:"value"`
+	Clock  uint64          `json:"clock"`
+	Client string          `json:"client"`
+}
+
+// NewLWW creates a new last-writer-wins adapter for the client.
+// Clients must be identified uniquely, e.g. by a random id per session.
+func NewLWW(client string) Adapter {
+	return &lww{client: client, entries: make(map[string]entry)}
+}
+
+// Local records the local edit with the next clock.
+func (l *lww) Local(field string, value json.RawMessage) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.clock++
+	e := entry{Field: field, Value: value, Clock: l.clock, Client: l.client}
+	l.entries[field] = e
+	return json.Marshal(e)
+}
+
+// Remote merges the remote edit unless a later edit of the field is known.
+func (l *lww) Remote(msg []byte) (map[string]json.RawMessage, error) {
+	var e entry
+	if err := json.Unmarshal(msg, &e); err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e.Clock > l.clock {
+		l.clock = e.Clock
+	}
+	if current, ok := l.entries[e.Field]; ok && !e.after(current) {
+		return nil, nil
+	}
+	l.entries[e.Field] = e
+	return map[string]json.RawMessage{e.Field: e.Value}, nil
+}
+
+// Snapshot returns the latest edits of all fields, which clients joining later merge.
+func (l *lww) Snapshot() ([][]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	msgs := make([][]byte, 0, len(l.entries))
+	for _, e := range l.entries {
+		msg, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// after determines if the edit is ordered after the other edit.
+func (e entry) after(other entry) bool {
+	if e.Clock != other.Clock {
+		return e.Clock > other.Clock
+	}
+	return e.Client > other.Client
+}
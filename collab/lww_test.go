@@ -0,0 +1,95 @@
+package collab
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLWWRemote(t *testing.T) {
+	tests := []struct {
+		name   string
+		local  []string
+		remote entry
+		want   map[string]json.RawMessage
+	}{
+		{"unknown field", nil, entry{Field: "Title", Value: json.RawMessage(`"b"`), Clock: 1, Client: "b"},
+			map[string]json.RawMessage{"Title": json.RawMessage(`"b"`)}},
+		{"later clock", []string{"Title"}, entry{Field: "Title", Value: json.RawMessage(`"b"`), Clock: 2, Client: "a"},
+			map[string]json.RawMessage{"Title": json.RawMessage(`"b"`)}},
+		{"earlier clock", []string{"Title", "Title"}, entry{Field: "Title", Value: json.RawMessage(`"b"`), Clock: 1, Client: "z"}, nil},
+		{"tie of greater client", []string{"Title"}, entry{Field: "Title", Value: json.RawMessage(`"b"`), Clock: 1, Client: "n"},
+			map[string]json.RawMessage{"Title": json.RawMessage(`"b"`)}},
+		{"tie of lesser client", []string{"Title"}, entry{Field: "Title", Value: json.RawMessage(`"b"`), Clock: 1, Client: "a"}, nil},
+		{"other field", []string{"Title", "Title"}, entry{Field: "Body", Value: json.RawMessage(`"b"`), Clock: 1, Client: "a"},
+			map[string]json.RawMessage{"Body": json.RawMessage(`"b"`)}},
+	}
+	for _, test := range tests {
+		l := NewLWW("m")
+		for _, field := range test.local {
+			if _, err := l.Local(field, json.RawMessage(`"m"`)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		msg, err := json.Marshal(test.remote)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := l.Remote(msg)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestLWWConverges(t *testing.T) {
+	a, b := NewLWW("a"), NewLWW("b")
+	msgA, _ := a.Local("Title", json.RawMessage(`"a"`))
+	msgB, _ := b.Local("Title", json.RawMessage(`"b"`))
+	gotA, _ := a.Remote(msgB)
+	gotB, _ := b.Remote(msgA)
+	if string(gotA["Title"]) != `"b"` || gotB != nil {
+		t.Errorf("concurrent edits did not converge to the greater client: got %s and %s", gotA, gotB)
+	}
+
+	// The clock advances past remote edits, so the next local edit is ordered after them.
+	msg, _ := a.Local("Title", json.RawMessage(`"a2"`))
+	if got, _ := b.Remote(msg); string(got["Title"]) != `"a2"` {
+		t.Errorf("later local edit was not merged: got %s", got)
+	}
+}
+
+func TestLWWSnapshot(t *testing.T) {
+	a := NewLWW("a")
+	a.Local("Title", json.RawMessage(`"t"`))
+	a.Local("Body", json.RawMessage(`"b"`))
+	msgs, err := a.(Snapshotter).Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	late := NewLWW("c")
+	got := make(map[string]json.RawMessage)
+	for _, msg := range msgs {
+		fields, err := late.Remote(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for field, value := range fields {
+			got[field] = value
+		}
+	}
+	want := map[string]json.RawMessage{"Title": json.RawMessage(`"t"`), "Body": json.RawMessage(`"b"`)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestLWWInvalid(t *testing.T) {
+	if _, err := NewLWW("a").Remote([]byte("{")); err == nil {
+		t.Error("invalid message has no error")
+	}
+}
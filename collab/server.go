@@ -0,0 +1,13 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package collab
+
+import (
+	"log"
+)
+
+// warn logs the error, since there is no console outside of the browser.
+func warn(err error) {
+	log.Printf("collab: %v", err)
+}
@@ -0,0 +1,68 @@
+//go:build js && wasm
+// +build js,wasm
+
+package collab
+
+import (
+	"errors"
+	"sync"
+	"syscall/js"
+)
+
+// Ready states of WebSockets.
+const (
+	connecting = 0
+	open       = 1
+)
+
+// WebSocket is a transport by a WebSocket relay which broadcasts messages to the other clients.
+// Messages sent while connecting are queued until the WebSocket opens.
+type WebSocket struct {
+	ws js.Value
+
+	mu     sync.Mutex
+	queued [][]byte
+}
+
+// Dial connects a WebSocket to the url of the relay.
+func Dial(url string) *WebSocket {
+	w := &WebSocket{ws: js.Global().Get("WebSocket").New(url)}
+	listen(w.ws, "open", func(js.Value) {
+		w.mu.Lock()
+		queued := w.queued
+		w.queued = nil
+		w.mu.Unlock()
+		for _, msg := range queued {
+			w.ws.Call("send", string(msg))
+		}
+	})
+	return w
+}
+
+// Send sends the message as text, or queues the message while connecting.
+func (w *WebSocket) Send(msg []byte) error {
+	switch w.ws.Get("readyState").Int() {
+	case connecting:
+		w.mu.Lock()
+		w.queued = append(w.queued, msg)
+		w.mu.Unlock()
+		return nil
+	case open:
+		w.ws.Call("send", string(msg))
+		return nil
+	default:
+		return errors.New("websocket is closed")
+	}
+}
+
+// OnMessage calls the function with received messages.
+func (w *WebSocket) OnMessage(fn func(msg []byte)) {
+	listen(w.ws, "message", func(event js.Value) {
+		fn([]byte(event.Get("data").String()))
+	})
+}
+
+// Close closes the WebSocket.
+func (w *WebSocket) Close() {
+	w.ws.Call("close")
+}
@@ -2,56 +2,176 @@
 package vue
 
 import (
-	"github.com/gowasm/go-js-dom"
+	"golang.org/x/net/html"
+	"net/http"
+	"strings"
+	"time"
 )
 
 // Comp is a vue component.
 type Comp struct {
-	el       dom.Element
+	el       element
+	name     string
 	tmpl     string
 	data     interface{}
 	methods  map[string]func(Context)
 	computed map[string]func(Context) interface{}
 	subs     map[string]*Comp
+	render   func(Context) *Node
 
-	props    map[string]interface{}
-	isSub    bool
-	callback callback
+	props     map[string]interface{}
+	propTypes map[string]propType
+	bound     map[string]struct{}
+	listeners map[string]func(interface{})
+	attrs     map[string]string
+	inherit   bool
+	theme     map[string]string
+	isSub     bool
+	callback  callback
+	alive     []string
+
+	functional func(map[string]interface{}) string
+
+	async   *async
+	loading *Comp
+	failure *Comp
+
+	reporter       func(Report)
+	fallback       string
+	concurrentDiff bool
+	lenient        bool
+
+	throttle      time.Duration
+	throttledAt   time.Time
+	throttledNode *html.Node
+	trailing      bool
+
+	lazy          bool
+	lazyScheduled bool
+	lazyMounted   bool
+
+	persistence *persistence
+	query       []string
+	updated     []func(Context)
+
+	mountedHooks   []func(Context)
+	unmountedHooks []func(Context)
+	unmounts       []func()
+	rendered       int
+	reused         int
+	queried        string
+	assets         http.FileSystem
+	assetURLs      map[string]string
+	captured       func(error, Context) bool
+	analytics      func(AnalyticsEvent)
+	mounted        bool
+	hydrate        bool
+	flags          *Flags
+	mixins         []interface{}
+	idle           *idle
+	visibility     *visibility
+	fullscreen     bool
+	battery        *battery
+	wakeLock       bool
+	recognition    string
+	serial         *serial
+	gamepad        bool
+
+	regions map[string]*region
+
+	vm       *ViewModel
+	def      *Comp
+	scope    string
+	parent   *Comp
+	provides map[string]interface{}
+	injects  []string
+}
+
+// registry is the global registry of components by element.
+var registry = make(map[string]*Comp)
+
+// Register registers the component globally by element.
+// Registered components are available to all components without the subcomponent option.
+func Register(element string, comp *Comp) {
+	registry[element] = comp
 }
 
 // Component creates a new component from the given options.
 func Component(options ...Option) *Comp {
+	comp := newComp()
+	comp.applyGlobal()
+	for _, option := range options {
+		option(comp)
+	}
+	return comp
+}
+
+// newComp creates a new component without options.
+func newComp() *Comp {
 	methods := make(map[string]func(Context), 0)
 	computed := make(map[string]func(Context) interface{}, 0)
 	subs := make(map[string]*Comp, 0)
 	props := make(map[string]interface{}, 0)
+	propTypes := make(map[string]propType, 0)
+	bound := make(map[string]struct{}, 0)
+	provides := make(map[string]interface{}, 0)
 
-	comp := &Comp{data: struct{}{}, methods: methods,
-		computed: computed, subs: subs, props: props}
-	for _, option := range options {
-		option(comp)
-	}
+	comp := &Comp{data: struct{}{}, methods: methods, computed: computed, subs: subs,
+		props: props, propTypes: propTypes, bound: bound, provides: provides, inherit: true}
 	return comp
 }
 
-// hasProp determines if a component has a prop.
+// propName returns the name of the prop which matches the attribute key.
+// Keys match case insensitively and kebab-case, e.g. todo-text matches the prop todoText.
 // Returns false for nil components.
-func (comp *Comp) hasProp(prop string) bool {
+func (comp *Comp) propName(key string) (string, bool) {
 	if comp == nil {
-		return false
+		return "", false
+	}
+	key = strings.Replace(key, "-", "", -1)
+	for prop := range comp.props {
+		if strings.EqualFold(key, prop) {
+			return prop, true
+		}
 	}
-	_, ok := comp.props[prop]
-	return ok
+	return "", false
 }
 
 // newSub attempts to creates a new subcomponent.
+// Subcomponents fall back to the global registry.
+// The subcomponent is the instance of the definition at the position within the root view model.
 // Returns false for unknown elements.
-func (comp *Comp) newSub(element string) (*Comp, bool) {
+func (comp *Comp) newSub(element, position string) (*Comp, bool) {
 	sub, ok := comp.subs[element]
 	if !ok {
-		return nil, false
+		sub, ok = registry[element]
+		if !ok {
+			return nil, false
+		}
 	}
+	sub = comp.callback.instance(comp, sub.resolve(comp.callback), element, position)
 	sub.isSub = true
+	sub.name = element
 	sub.callback = comp.callback
+	sub.parent = comp
+	sub.listeners = make(map[string]func(interface{}))
 	return sub, true
 }
+
+// keepAlive keeps the subcomponent alive in the order of first visit.
+func (comp *Comp) keepAlive(element string) {
+	for _, alive := range comp.alive {
+		if alive == element {
+			return
+		}
+	}
+	comp.alive = append(comp.alive, element)
+}
+
+// root returns the root component of the component.
+func (comp *Comp) root() *Comp {
+	for comp.parent != nil {
+		comp = comp.parent
+	}
+	return comp
+}
@@ -0,0 +1,42 @@
+package vue
+
+// The data of components is owned by the render loop,
+// i.e. the event, browser timer and animation frame callbacks of the root view model.
+// Methods, watchers and renders run on the render loop and may access data directly.
+// Other goroutines must not access data directly, instead they mutate data by Update or Go,
+// which queue mutations under the lock of the root view model to be applied before the next render.
+// Timers of time.AfterFunc and factories of async components run on goroutines, so they update likewise,
+// while transitions touch the dom from browser timers.
+// Scheduling renders is locked, so ForceUpdate and Flags are safe to call from any goroutine.
+// The root view models of the page are locked as well, so plugins update them from any goroutine,
+// e.g. by Installer.Update from the actions of a store, while roots are created and unmounted.
+//
+// Renders execute templates on the render loop. With the concurrent diff option,
+// the executed node is diffed against the virtual dom by a goroutine which queues the dom patches,
+// then the patches are applied on the next animation frame. The virtual dom is owned by the diff
+// until the patches are applied, so renders are postponed meanwhile.
+
+// ConcurrentDiff is the concurrent diff option for root components.
+// The diff of renders is computed off the render loop before the dom is patched,
+// e.g. to keep large renders from blocking input handlers.
+func ConcurrentDiff() Option {
+	return func(comp *Comp) {
+		comp.concurrentDiff = true
+	}
+}
+
+// patches are the dom patches of a virtual dom.
+// Patches are applied immediately unless deferred, which queues them to be applied later.
+type patches struct {
+	deferred bool
+	queue    []func()
+}
+
+// do applies or queues the patch.
+func (p *patches) do(patch func()) {
+	if p == nil || !p.deferred {
+		patch()
+		return
+	}
+	p.queue = append(p.queue, patch)
+}
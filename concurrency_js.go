@@ -0,0 +1,69 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"golang.org/x/net/html"
+)
+
+// share shares the patches with the virtual dom of the node.
+func (vnode *vnode) share(p *patches) {
+	vnode.patches = p
+	for child := vnode.firstChild; child != nil; child = child.nextSibling {
+		child.share(p)
+	}
+}
+
+// diff diffs by the render against the virtual dom and returns the dom patches without applying them.
+func (p *patches) diff(render func()) []func() {
+	p.deferred = true
+	render()
+	queue := p.queue
+	p.deferred = false
+	p.queue = nil
+	return queue
+}
+
+// diff diffs the executed node by a goroutine then patches the dom on the next animation frame.
+func (vm *ViewModel) diff(node *html.Node) {
+	vm.mu.Lock()
+	vm.patching = true
+	vm.mu.Unlock()
+
+	go func() {
+		defer vm.report()
+		queue := vm.vnode.patches.diff(func() {
+			vm.renderNode(node)
+		})
+		vm.mu.Lock()
+		vm.patches = queue
+		vm.mu.Unlock()
+		requestAnimationFrame(vm.patch)
+	}()
+}
+
+// patch applies the dom patches of the diff then calls queued ticks.
+// Renders which were postponed by the diff are scheduled.
+func (vm *ViewModel) patch() {
+	defer vm.report()
+	vm.mu.Lock()
+	queue := vm.patches
+	pending := vm.pending
+	vm.patches, vm.pending, vm.patching = nil, false, false
+	vm.mu.Unlock()
+	if vm.unmounted {
+		return
+	}
+
+	state := captureView()
+	for _, patch := range queue {
+		patch()
+	}
+	state.restore()
+	vm.tick()
+
+	if pending {
+		vm.render()
+	}
+}
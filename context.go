@@ -4,14 +4,43 @@ import (
 	"fmt"
 	"github.com/fatih/structs"
 	"reflect"
+	"strings"
 )
 
+// tagName is the struct tag which names fields in templates.
+const tagName = "vue"
+
 // Context is received by methods to interact with the component.
 type Context interface {
 	Data() interface{}
 	Get(field string) interface{}
 	Set(field string, value interface{})
 	Call(method string)
+	NextTick(fn func())
+	Go(fn func() error)
+	Update(fn func(data interface{}))
+	OnUnmount(fn func())
+	Locale() string
+	SetLocale(locale string)
+	Emit(event string, value interface{})
+
+	// Mutation helpers render after changing slices and maps in place.
+	// Any call is a change, direct mutations render only from methods or by ForceUpdate.
+	Push(field string, values ...interface{})
+	Splice(field string, start, count int, values ...interface{})
+	SetKey(field string, key, value interface{})
+	DeleteKey(field string, key interface{})
+
+	// The dom of the browser is within the context, which is empty on the server.
+	Ref(name string) Value
+	Event() *Event
+}
+
+// Event returns the dom event which triggered the method.
+// Returns nil outside of event methods.
+// The event is handled by the root view model.
+func (vm *ViewModel) Event() *Event {
+	return vm.comp.root().vm.event
 }
 
 // Data returns the data for the component.
@@ -20,11 +49,11 @@ func (vm *ViewModel) Data() interface{} {
 	return vm.comp.data
 }
 
-// Get returns the data field value.
+// Get returns the data field value by the dotted path.
 // Props and computed are included to get.
 // Computed may be calculated as needed.
 func (vm *ViewModel) Get(field string) interface{} {
-	value, ok := vm.data[field]
+	value, ok := resolvePath(vm.data, field)
 	if !ok {
 		function, ok := vm.comp.computed[field]
 		if !ok {
@@ -34,29 +63,90 @@ func (vm *ViewModel) Get(field string) interface{} {
 		value = function(vm)
 		vm.data[field] = value
 	}
-	return value
+	return unformat(value)
 }
 
-// Set assigns the data field to the given value.
+// Set assigns the data field of the dotted path to the given value.
 // Props and computed are excluded to set.
 func (vm *ViewModel) Set(field string, value interface{}) {
-	data := reflect.Indirect(reflect.ValueOf(vm.comp.data))
-	val := reflect.Indirect(data.FieldByName(field))
-	val.Set(reflect.Indirect(reflect.ValueOf(value)))
+	vm.breadcrumb("set: %s", field)
+	vm.comp.setPath(field, value)
 }
 
 // Call calls the given method then calls render.
+// Methods of subcomponents bound by events are called with the context of their instance.
 func (vm *ViewModel) Call(method string) {
-	if function, ok := vm.comp.methods[method]; ok {
-		function(vm)
+	if function, comp, ok := vm.method(method); ok {
+		function(comp.context())
 		vm.render()
 	}
 }
 
-// mapData creates a map from data, props and computed.
+// method returns the method by key and its component.
+// Keys of methods of subcomponents are prefixed by the scope of their instance.
+func (vm *ViewModel) method(key string) (func(Context), *Comp, bool) {
+	if function, ok := vm.comp.methods[key]; ok {
+		return function, vm.comp, true
+	}
+	i := strings.Index(key, ":")
+	if i < 0 {
+		return nil, nil, false
+	}
+	sub, ok := vm.scoped[key[:i]]
+	if !ok {
+		return nil, nil, false
+	}
+	function, ok := sub.methods[key[i+1:]]
+	return function, sub, ok
+}
+
+// methodKey returns the key of the method of the component which is called by events of the root view model.
+// Methods of subcomponents are keyed by the scope of their instance.
+func (comp *Comp) methodKey(method string) string {
+	if _, ok := comp.methods[method]; !ok || !comp.isSub {
+		return method
+	}
+	return comp.scopedKey(method)
+}
+
+// scopedKey returns the key of the name prefixed by the scope of the instance of the subcomponent,
+// which is registered to the root view model.
+func (comp *Comp) scopedKey(name string) string {
+	root := comp.root().vm
+	scope := comp.instanceScope()
+	if root.scoped == nil {
+		root.scoped = make(map[string]*Comp)
+	}
+	root.scoped[scope] = comp
+	return scope + ":" + name
+}
+
+// NextTick calls the function after the next render updates the dom,
+// e.g. to measure or manipulate freshly rendered elements.
+func (vm *ViewModel) NextTick(fn func()) {
+	vm.comp.callback.nextTick(fn)
+}
+
+// nextTick queues the function to be called after the next render of the root view model.
+func (vm *ViewModel) nextTick(fn func()) {
+	vm.ticks = append(vm.ticks, fn)
+	vm.render()
+}
+
+// mapData creates a map from data, mixins, props, injections and computed.
 func (vm *ViewModel) mapData() {
-	vm.data = structs.Map(vm.comp.data)
+	vm.data = mapStruct(vm.comp.data)
+	vm.mixinData()
+	vm.idleData()
+	vm.visibilityData()
+	vm.fullscreenData()
+	vm.batteryData()
+	vm.wakeLockData()
+	vm.serialData()
+	vm.gamepadsData()
 	vm.props()
+	vm.inject()
+	vm.attrsData()
 	vm.computed()
 }
 
@@ -75,3 +165,43 @@ func (vm *ViewModel) computed() {
 		}
 	}
 }
+
+// mapStruct creates a map from the struct with fields named by the vue tag.
+// Fields of embedded structs are promoted unless shadowed.
+// Values are formatted for interpolation.
+func mapStruct(data interface{}) map[string]interface{} {
+	s := structs.New(data)
+	s.TagName = tagName
+	m := s.Map()
+	val := reflect.Indirect(reflect.ValueOf(data))
+	formatFields(val, m)
+	promote(val.Type(), m)
+	return m
+}
+
+// promote recursively promotes the fields of embedded structs of the type within the map.
+func promote(typ reflect.Type, m map[string]interface{}) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		embedded, ok := m[fieldName(field)].(map[string]interface{})
+		if !field.Anonymous || !ok {
+			continue
+		}
+		promote(indirectType(field.Type), embedded)
+		for key, value := range embedded {
+			if _, ok := m[key]; !ok {
+				m[key] = value
+			}
+		}
+	}
+}
+
+// fieldName returns the name of the struct field by the vue tag, e.g. `vue:"todoText"`.
+// The name of the field is returned without a tag name.
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get(tagName), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,80 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+	"sort"
+	"strings"
+)
+
+// directives are the registered custom directives by name.
+var directives = make(map[string]func(*Binding))
+
+// Binding is the binding of a custom directive to an element, e.g. v-tooltip:top="Hint".
+type Binding struct {
+	// Arg is the argument of the directive, e.g. top.
+	Arg string
+	// Value is the value of the bound data field, e.g. Hint, which is nil without a field.
+	Value interface{}
+	// Attrs are the attributes of the element, which may be modified by the directive.
+	// Vue attributes are excluded.
+	Attrs Attrs
+}
+
+// Directive registers the custom directive globally by name, e.g. tooltip for v-tooltip.
+// Directives are executed after the built in vue attributes of the element.
+func Directive(name string, directive func(binding *Binding)) {
+	directives[name] = directive
+}
+
+// executeAttrDirective executes the custom directive of the vue attribute.
+// Returns false for unknown directives.
+func (tmpl *template) executeAttrDirective(node *html.Node, typ, arg, field string, data map[string]interface{}) bool {
+	directive, ok := directives[strings.TrimPrefix(typ, v)]
+	if !ok {
+		return false
+	}
+	tmpl.effects++
+
+	var value interface{}
+	if field != "" {
+		value, _ = tmpl.lookup(data, field)
+	}
+	binding := &Binding{Arg: arg, Value: value, Attrs: make(Attrs)}
+	for _, attr := range node.Attr {
+		if !strings.HasPrefix(attr.Key, v) {
+			binding.Attrs[attr.Key] = attr.Val
+		}
+	}
+	directive(binding)
+	setAttrs(node, binding.Attrs)
+	return true
+}
+
+// setAttrs sets the attributes of the node other than vue attributes, which remain in order.
+// Existing attributes keep their order then new attributes are sorted.
+func setAttrs(node *html.Node, attrs Attrs) {
+	seen := make(map[string]struct{}, len(node.Attr))
+	result := make([]html.Attribute, 0, len(attrs))
+	for _, attr := range node.Attr {
+		if strings.HasPrefix(attr.Key, v) {
+			result = append(result, attr)
+			continue
+		}
+		seen[attr.Key] = struct{}{}
+		if val, ok := attrs[attr.Key]; ok {
+			result = append(result, html.Attribute{Key: attr.Key, Val: val})
+		}
+	}
+
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		if _, ok := seen[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		result = append(result, html.Attribute{Key: key, Val: attrs[key]})
+	}
+	node.Attr = result
+}
@@ -0,0 +1,68 @@
+package vue
+
+import (
+	"fmt"
+	"strings"
+)
+
+// updateEvent is the prefix of the events which update the field bound to a prop by the model attribute,
+// e.g. update:Body of <rich-text v-model:Body="Body">.
+const updateEvent = "update:"
+
+// Emit emits the event with the value to the listener of the parent of the subcomponent, then renders,
+// e.g. the update event of a prop bound by the model attribute of the parent, which is named update:Body for the prop Body.
+// Events without listeners are ignored.
+func (vm *ViewModel) Emit(event string, value interface{}) {
+	vm.breadcrumb("emit: %s", event)
+	if listener, ok := vm.comp.listeners[event]; ok {
+		listener(value)
+	}
+	vm.render()
+}
+
+// executeModelProp binds the prop of the subcomponent to the field of the model attribute,
+// e.g. <rich-text v-model:Body="Body">. The field is set by the update event of the prop emitted by the subcomponent.
+func (tmpl *template) executeModelProp(sub *Comp, part, field string, data map[string]interface{}) {
+	prop, ok := sub.propName(part)
+	if !ok {
+		must(fmt.Errorf("unknown prop of the model attribute: %s", part))
+	}
+	if value, ok := tmpl.lookup(data, field); ok {
+		sub.bindProp(prop, tmpl.propValue(sub, prop, field, unformat(value)))
+	}
+	parent := tmpl.comp
+	sub.listeners[updateEvent+prop] = func(value interface{}) {
+		parent.setModel(field, value)
+	}
+}
+
+// setModel sets the field of the component bound by the model attribute.
+// Props are owned by the parent, so subcomponents emit the update event of the prop instead.
+func (comp *Comp) setModel(field string, value interface{}) {
+	if prop, ok := comp.propName(field); ok && comp.isSub {
+		if listener, ok := comp.listeners[updateEvent+prop]; ok {
+			listener(value)
+		}
+		return
+	}
+	comp.setPath(field, value)
+}
+
+// modelKey returns the key of the field of the model attribute which is set by events of the root view model.
+// Fields of subcomponents are keyed by the scope of their instance, like methods.
+func (comp *Comp) modelKey(field string) string {
+	if !comp.isSub {
+		return field
+	}
+	return comp.scopedKey(field)
+}
+
+// model returns the component and field of the model by key.
+func (vm *ViewModel) model(key string) (*Comp, string) {
+	if i := strings.Index(key, ":"); i >= 0 {
+		if sub, ok := vm.scoped[key[:i]]; ok {
+			return sub, key[i+1:]
+		}
+	}
+	return vm.comp, key
+}
@@ -0,0 +1,99 @@
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"regexp"
+	"strings"
+)
+
+// TemplateError is an error which originates from executing a directive or interpolation of a template.
+// The error names the component by element and the snippet of the template, e.g. <li v-for="Todo in Todos">,
+// the path of the element, e.g. div > ol > li[v-for], and the line and column of the snippet.
+// The line and column are zero when the snippet is not found in the template, e.g. for render functions.
+type TemplateError struct {
+	Component string
+	Snippet   string
+	Path      string
+	Line      int
+	Column    int
+	Err       error
+}
+
+// Error returns the error with the component, the location and the snippet of the template.
+func (err *TemplateError) Error() string {
+	location := err.Path
+	if err.Line > 0 {
+		location = fmt.Sprintf("%s at %d:%d", location, err.Line, err.Column)
+	}
+	return fmt.Sprintf("%s: %s: %s: %v", err.Component, location, err.Snippet, err.Err)
+}
+
+// Unwrap returns the underlying error.
+func (err *TemplateError) Unwrap() error {
+	return err.Err
+}
+
+// loopVar matches the renamed variables of executed for attributes, e.g. Todo3.
+var loopVar = regexp.MustCompile(`([A-Za-z_])\d+\b`)
+
+// wrapErr recovers a panic from executing the snippet of the node then panics again with the template error.
+// The key names the attribute of the snippet and the source is the text to locate in the template.
+// Errors are wrapped once by the innermost snippet. WrapErr must be deferred directly to recover.
+func (tmpl *template) wrapErr(node *html.Node, key, snippet, source string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if _, ok := r.(*TemplateError); ok {
+		panic(r)
+	}
+
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("%v", r)
+	}
+	path := nodePath(node)
+	if key != "" {
+		path = fmt.Sprintf("%s[%s]", path, key)
+	}
+	line, column := position(tmpl.comp.tmpl, source)
+	if line == 0 {
+		line, column = position(tmpl.comp.tmpl, loopVar.ReplaceAllString(source, "$1"))
+	}
+	panic(&TemplateError{Component: tmpl.comp.displayName(), Snippet: snippet, Path: path,
+		Line: line, Column: column, Err: err})
+}
+
+// nodePath returns the path of the elements from the root of the template to the node, e.g. div > ol > li.
+// Text nodes are located by their parent element.
+func nodePath(node *html.Node) string {
+	if node.Type == html.TextNode {
+		node = node.Parent
+	}
+	var path []string
+	for ; node != nil && node.Type == html.ElementNode && node.Data != ""; node = node.Parent {
+		path = append([]string{node.Data}, path...)
+	}
+	return strings.Join(path, " > ")
+}
+
+// position returns the line and column of the first occurrence of the source in the template.
+// Returns zeros when the source is not found.
+func position(tmpl, source string) (int, int) {
+	i := strings.Index(tmpl, source)
+	if source == "" || i < 0 {
+		return 0, 0
+	}
+	line := strings.Count(tmpl[:i], "\n") + 1
+	column := i - strings.LastIndex(tmpl[:i], "\n")
+	return line, column
+}
+
+// displayName returns the element of the component, or root for the root component.
+func (comp *Comp) displayName() string {
+	if comp.name == "" {
+		return "root"
+	}
+	return comp.name
+}
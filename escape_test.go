@@ -0,0 +1,55 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"errors"
+	"testing"
+)
+
+type escapeData struct {
+	Name string
+}
+
+func TestRenderEscaping(t *testing.T) {
+	Messages("en", map[string]string{"escape.greeting": "Hello, {Name}"})
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{"interpolation", `<p>{{ Name }}</p>`, `<p>Tom &amp; &#34;Jerry&#34; &lt;3</p>`},
+		{"translation", `<p>{{ $t("escape.greeting", Name) }}</p>`, `<p>Hello, Tom &amp; &#34;Jerry&#34; &lt;3</p>`},
+		{"literal", `<p>&amp; {{ Name }}</p>`, `<p>&amp; Tom &amp; &#34;Jerry&#34; &lt;3</p>`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			comp := Component(Template(test.tmpl), Data(&escapeData{Name: `Tom & "Jerry" <3`}))
+			html, err := RenderToString(comp, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if html != test.want {
+				t.Errorf("got %s, want %s", html, test.want)
+			}
+		})
+	}
+}
+
+func TestBoundaryEscaping(t *testing.T) {
+	broken := Component(
+		Template(`<p>{{ Name }}</p>`),
+		Computed(func(Context) interface{} {
+			panic(errors.New(`<b> & "c"`))
+		}),
+	)
+	comp := Component(
+		Template(`<div><error-boundary name="escape"><broken></broken><p slot="fallback">{{ Err }}</p></error-boundary></div>`),
+		Sub("broken", broken),
+	)
+	vm := Headless(comp, nil)
+	if got, want := vm.HTML(), `<div><p data-error-boundary="escape">&lt;b&gt; &amp; &#34;c&#34;</p></div>`; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
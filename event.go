@@ -0,0 +1,119 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"github.com/gowasm/go-js-dom"
+	"strings"
+	"syscall/js"
+)
+
+// modifierCallbacks are the listeners of the modifier attributes, which are shared by elements.
+// The listeners prevent the default action or stop the propagation synchronously, which methods cannot.
+var modifierCallbacks = map[string]js.Callback{
+	preventAttr: js.NewEventCallback(js.PreventDefault, func(js.Value) {}),
+	stopAttr:    js.NewEventCallback(js.StopPropagation, func(js.Value) {}),
+}
+
+// listenModifiers replaces the listeners of the types of events of the modifier attribute of the element.
+func listenModifiers(el js.Value, key, old, val string) {
+	cb, ok := modifierCallbacks[key]
+	if !ok {
+		return
+	}
+	for _, typ := range strings.Fields(old) {
+		el.Call("removeEventListener", typ, cb)
+	}
+	for _, typ := range strings.Fields(val) {
+		el.Call("addEventListener", typ, cb)
+	}
+}
+
+// Event is the dom event which triggered a method.
+type Event struct {
+	event dom.Event
+}
+
+// Type returns the type of the event, e.g. click.
+func (e *Event) Type() string {
+	return e.event.Type()
+}
+
+// Value returns the value of the target element, e.g. of an input.
+func (e *Event) Value() string {
+	return e.event.Target().Underlying().Get("value").String()
+}
+
+// Checked determines if the target element is checked, e.g. a checkbox.
+func (e *Event) Checked() bool {
+	return e.event.Target().Underlying().Get("checked").Bool()
+}
+
+// Key returns the key of keyboard events, e.g. Enter.
+// Returns empty for other types of events.
+func (e *Event) Key() string {
+	key := e.prop("key")
+	if key == js.Undefined() {
+		return ""
+	}
+	return key.String()
+}
+
+// ClientX returns the horizontal coordinate of mouse events within the viewport.
+// Returns zero for other types of events.
+func (e *Event) ClientX() float64 {
+	return e.number("clientX")
+}
+
+// ClientY returns the vertical coordinate of mouse events within the viewport.
+// Returns zero for other types of events.
+func (e *Event) ClientY() float64 {
+	return e.number("clientY")
+}
+
+// DataTransfer returns the data transfer of drag and drop events.
+func (e *Event) DataTransfer() Value {
+	return e.prop("dataTransfer")
+}
+
+// Files returns the files of the target file input.
+func (e *Event) Files() Value {
+	return e.event.Target().Underlying().Get("files")
+}
+
+// Target returns the target element of the event.
+func (e *Event) Target() dom.Element {
+	return e.event.Target()
+}
+
+// PreventDefault has no effect, since methods are called after the event is dispatched.
+// The prevent modifier prevents the default action instead, e.g. v-on:submit.prevent="Save".
+func (e *Event) PreventDefault() {
+	e.event.PreventDefault()
+}
+
+// StopPropagation has no effect, since methods are called after the event is dispatched.
+// The stop modifier stops the propagation instead, e.g. v-on:click.stop="Select".
+func (e *Event) StopPropagation() {
+	e.event.StopPropagation()
+}
+
+// Underlying returns the underlying js event.
+func (e *Event) Underlying() Value {
+	return e.event.Underlying()
+}
+
+// prop returns the property of the event, which is undefined for other types of events.
+func (e *Event) prop(key string) js.Value {
+	return e.event.Underlying().Get(key)
+}
+
+// number returns the number property of the event, which is zero for other types of events.
+func (e *Event) number(key string) float64 {
+	value := e.prop(key)
+	if value == js.Undefined() {
+		return 0
+	}
+	return value.Float()
+}
@@ -0,0 +1,25 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"github.com/norunners/vue"
+)
+
+type Props struct {
+	Label    string `prop:"required"`
+	Count    int
+	Disabled bool
+}
+
+func main() {
+	vue.Register("counter-button", vue.Component(
+		vue.PropTypes(Props{Label: "Clicks"}),
+		vue.Template(`<button v-bind:disabled="Disabled" v-track="counter_click">{{ Label }}: {{ Count }}</button>`),
+	))
+
+	vue.Playground("#app")
+
+	select {}
+}
@@ -0,0 +1,174 @@
+// Package extract extracts the keys of translations from templates and Go source,
+// then updates the catalogs of messages by locale, e.g. of LazyMessages, where missing keys are flagged.
+//
+// Templates translate by $t and $tc, e.g. {{ $t("welcome") }}, in .vue, .html and .tmpl files
+// and in the string literals of Go source. Go templates translate by the t function, e.g. {{ t "welcome" }}.
+// Go source also translates by calls of T functions, e.g. i18n.T("welcome").
+// Keys are extracted from quoted keys only, so keys of variables are not found.
+package extract
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Missing is the prefix of the messages of missing keys, which are flagged for translation,
+// e.g. "welcome": "TODO: welcome". Flagged messages render with the prefix until translated.
+const Missing = "TODO: "
+
+// translateCall matches the translations of templates by their quoted key,
+// e.g. $t("welcome") or $tc("items", Count).
+var translateCall = regexp.MustCompile(`\$tc?\(\s*("(?:[^"\\]|\\.)*")`)
+
+// translateFunc matches the translations of Go templates by the t function, e.g. {{ t "welcome" }} or {{- t "items" .Count }}.
+var translateFunc = regexp.MustCompile(`\{\{-?\s*t\s+("(?:[^"\\]|\\.)*")`)
+
+// templateExts are the extensions of template files.
+var templateExts = map[string]struct{}{".vue": {}, ".html": {}, ".tmpl": {}}
+
+// Dir extracts the sorted keys of the templates and Go source of the directory and its subdirectories.
+// Hidden directories and vendor directories are skipped.
+func Dir(root string) ([]string, error) {
+	keys := make(map[string]struct{})
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if name := info.Name(); path != root && (strings.HasPrefix(name, ".") || name == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if _, ok := templateExts[ext]; !ok && ext != ".go" {
+			return nil
+		}
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		found, err := File(path, src)
+		if err != nil {
+			return err
+		}
+		for _, key := range found {
+			keys[key] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sorted(keys), nil
+}
+
+// File extracts the sorted keys of the source of the file by name,
+// which is Go source by the .go extension, otherwise a template.
+func File(name string, src []byte) ([]string, error) {
+	keys := make(map[string]struct{})
+	if filepath.Ext(name) != ".go" {
+		template(string(src), keys)
+		return sorted(keys), nil
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), name, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.BasicLit:
+			if text, ok := stringLit(node); ok {
+				template(text, keys)
+			}
+		case *ast.CallExpr:
+			if !isT(node.Fun) || len(node.Args) == 0 {
+				break
+			}
+			if lit, ok := node.Args[0].(*ast.BasicLit); ok {
+				if key, ok := stringLit(lit); ok {
+					keys[key] = struct{}{}
+				}
+			}
+		}
+		return true
+	})
+	return sorted(keys), nil
+}
+
+// Update adds the missing keys to the catalog, flagged by the missing prefix.
+// Returns the sorted keys which are missing, including flagged keys which are still untranslated,
+// and the sorted keys of the catalog which are unused.
+func Update(catalog map[string]string, keys []string) (missing, unused []string) {
+	used := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		used[key] = struct{}{}
+		msg, ok := catalog[key]
+		if !ok {
+			msg = Missing + key
+			catalog[key] = msg
+		}
+		if strings.HasPrefix(msg, Missing) {
+			missing = append(missing, key)
+		}
+	}
+	for key := range catalog {
+		if _, ok := used[key]; !ok {
+			unused = append(unused, key)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unused)
+	return missing, unused
+}
+
+// template extracts the keys of the translations of the template.
+func template(text string, keys map[string]struct{}) {
+	for _, re := range []*regexp.Regexp{translateCall, translateFunc} {
+		for _, match := range re.FindAllStringSubmatch(text, -1) {
+			if key, err := strconv.Unquote(match[1]); err == nil {
+				keys[key] = struct{}{}
+			}
+		}
+	}
+}
+
+// stringLit returns the text of the string literal.
+func stringLit(lit *ast.BasicLit) (string, bool) {
+	if lit.Kind != token.STRING {
+		return "", false
+	}
+	text, err := strconv.Unquote(lit.Value)
+	return text, err == nil
+}
+
+// isT determines if the function is named T, e.g. T or i18n.T.
+func isT(fun ast.Expr) bool {
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		return fun.Name == "T"
+	case *ast.SelectorExpr:
+		return fun.Sel.Name == "T"
+	default:
+		return false
+	}
+}
+
+// sorted returns the sorted keys of the set.
+func sorted(keys map[string]struct{}) []string {
+	list := make([]string, 0, len(keys))
+	for key := range keys {
+		list = append(list, key)
+	}
+	sort.Strings(list)
+	return list
+}
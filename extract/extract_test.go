@@ -0,0 +1,79 @@
+package extract
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"page.vue", `<p>{{ $t("welcome") }} {{ $tc("items", Count) }}</p>`, []string{"items", "welcome"}},
+		{"page.html", `<p>{{ $t("greeting", User.Name, "!") }} {{ $t( "spaced" ) }}</p>`, []string{"greeting", "spaced"}},
+		{"page.vue", `<p>{{ $t("say \"hi\"") }}</p>`, []string{`say "hi"`}},
+		{"page.tmpl", `<p>{{ t "welcome" }} {{- t "items" .Count }} {{ title "ignored" }}</p>`, []string{"items", "welcome"}},
+		{"page.html", `<p>{{ Name }} {{ $t(Key) }}</p>`, []string{}},
+		{"main.go", "package main\n\nconst tmpl = `<p>{{ $t(\"welcome\") }}</p>`\n\nvar hello = \"{{ $tc(\\\"items\\\", Count) }}\"\n", []string{"items", "welcome"}},
+		{"main.go", "package main\n\nfunc main() {\n\ti18n.T(\"go.key\")\n\tT(\"local.key\")\n\tfmt.Println(\"not.key\")\n}\n", []string{"go.key", "local.key"}},
+	}
+	for _, test := range tests {
+		got, err := File(test.name, []byte(test.src))
+		if err != nil {
+			t.Errorf("%s: %v", test.src, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %q, want %q", test.src, got, test.want)
+		}
+	}
+}
+
+func TestFileInvalidGo(t *testing.T) {
+	if _, err := File("main.go", []byte("package")); err == nil {
+		t.Error("invalid Go source has no error")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	tests := []struct {
+		catalog     map[string]string
+		keys        []string
+		want        map[string]string
+		wantMissing []string
+		wantUnused  []string
+	}{
+		{
+			catalog:     map[string]string{},
+			keys:        []string{"welcome"},
+			want:        map[string]string{"welcome": "TODO: welcome"},
+			wantMissing: []string{"welcome"},
+		},
+		{
+			catalog: map[string]string{"welcome": "Bienvenue"},
+			keys:    []string{"welcome"},
+			want:    map[string]string{"welcome": "Bienvenue"},
+		},
+		{
+			catalog:     map[string]string{"welcome": "TODO: welcome", "old": "Vieux"},
+			keys:        []string{"items", "welcome"},
+			want:        map[string]string{"items": "TODO: items", "welcome": "TODO: welcome", "old": "Vieux"},
+			wantMissing: []string{"items", "welcome"},
+			wantUnused:  []string{"old"},
+		},
+	}
+	for i, test := range tests {
+		missing, unused := Update(test.catalog, test.keys)
+		if !reflect.DeepEqual(test.catalog, test.want) {
+			t.Errorf("%d: got catalog %v, want %v", i, test.catalog, test.want)
+		}
+		if !reflect.DeepEqual(missing, test.wantMissing) {
+			t.Errorf("%d: got missing %q, want %q", i, missing, test.wantMissing)
+		}
+		if !reflect.DeepEqual(unused, test.wantUnused) {
+			t.Errorf("%d: got unused %q, want %q", i, unused, test.wantUnused)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package vue
+
+import (
+	"fmt"
+	"github.com/cbroglie/mustache"
+	"golang.org/x/net/html"
+)
+
+// NewE creates a new view model from the given options like New,
+// but returns errors instead of panicking, e.g. unknown data fields of the first render.
+// Errors which are handled by the reporter or fallback options are not returned.
+func NewE(options ...Option) (vm *ViewModel, err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		vm = nil
+		if err, _ = r.(error); err == nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return New(options...), nil
+}
+
+// Fallback is the fallback option for root components.
+// Errors from renders and event handlers render the fallback template instead of panicking,
+// e.g. to show a message and a reload button while the instance is broken.
+// The error message is rendered by the Err field, e.g. {{ Err }}.
+func Fallback(tmpl string) Option {
+	return func(comp *Comp) {
+		comp.fallback = tmpl
+	}
+}
+
+// hasFallback determines if the view model renders a fallback on errors.
+// Subcomponents render within the root, so only the root renders a fallback.
+func (vm *ViewModel) hasFallback() bool {
+	return vm.comp.fallback != "" && !vm.comp.isSub
+}
+
+// fallbackNode executes the fallback template with the error.
+func (vm *ViewModel) fallbackNode(err error) *html.Node {
+	text, renderErr := mustache.Render(vm.comp.fallback, map[string]interface{}{"Err": err.Error()})
+	if renderErr != nil {
+		text = vm.comp.fallback
+	}
+	return parseNode(text)
+}
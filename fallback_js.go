@@ -0,0 +1,9 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+// renderFallback renders the fallback template with the error immediately.
+func (vm *ViewModel) renderFallback(err error) {
+	vm.vnode.render(vm.fallbackNode(err))
+}
@@ -0,0 +1,108 @@
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// filters are the registered filters by name, including the built in filters.
+var filters = map[string]func(interface{}, ...string) interface{}{
+	"upper":    upper,
+	"lower":    lower,
+	"truncate": truncate,
+	"date":     date,
+	"number":   number,
+	"currency": currency,
+}
+
+// pipeTag matches interpolations with pipes, e.g. {{ Name | upper | truncate 20 }}.
+var pipeTag = regexp.MustCompile(`\{\{\s*([^\s{}#^/!&>=][^{}]*?\|[^{}]*?)\s*\}\}`)
+
+// Filter registers the filter globally by name.
+// Filters transform interpolated values for presentation by pipes, e.g. {{ Name | upper | truncate 20 }},
+// where the words after the name of a filter are its arguments and quoted arguments may contain spaces.
+func Filter(name string, filter func(value interface{}, args ...string) interface{}) {
+	filters[name] = filter
+}
+
+// executePipes executes the pipes of the text node into data fields,
+// which are interpolated in place of the pipes.
+// Unknown fields are errors, unless the component is lenient, which pipes them as nil.
+// The keys of the fields are not valid field names, so they do not collide with the data.
+func (tmpl *template) executePipes(node *html.Node, data map[string]interface{}) {
+	node.Data = pipeTag.ReplaceAllStringFunc(node.Data, func(tag string) string {
+		stages := strings.Split(pipeTag.FindStringSubmatch(tag)[1], "|")
+		value, _ := tmpl.lookup(data, strings.TrimSpace(stages[0]))
+		value = unformat(value)
+		for _, stage := range stages[1:] {
+			args := pipeArgs(stage)
+			if len(args) == 0 {
+				must(fmt.Errorf("empty filter in pipe: %s", tag))
+			}
+			filter, ok := filters[args[0]]
+			if !ok {
+				must(fmt.Errorf("unknown filter: %s", args[0]))
+			}
+			value = filter(value, args[1:]...)
+		}
+
+		key := fmt.Sprintf("$pipe%d", tmpl.id)
+		tmpl.id++
+		data[key] = value
+		return "{{" + key + "}}"
+	})
+}
+
+// pipeArgs splits the stage of a pipe into the filter name and its arguments.
+// Quoted arguments are unquoted.
+func pipeArgs(stage string) []string {
+	var args []string
+	for stage = strings.TrimSpace(stage); stage != ""; stage = strings.TrimSpace(stage) {
+		if stage[0] == '"' {
+			end := strings.Index(stage[1:], `"`) + 2
+			if end == 1 {
+				must(fmt.Errorf("unterminated argument: %s", stage))
+			}
+			arg, err := strconv.Unquote(stage[:end])
+			must(err)
+			args = append(args, arg)
+			stage = stage[end:]
+			continue
+		}
+		end := strings.IndexAny(stage, " \t")
+		if end < 0 {
+			end = len(stage)
+		}
+		args = append(args, stage[:end])
+		stage = stage[end:]
+	}
+	return args
+}
+
+// upper is the filter which upper cases the text of the value.
+func upper(value interface{}, _ ...string) interface{} {
+	return strings.ToUpper(fmt.Sprint(value))
+}
+
+// lower is the filter which lower cases the text of the value.
+func lower(value interface{}, _ ...string) interface{} {
+	return strings.ToLower(fmt.Sprint(value))
+}
+
+// truncate is the filter which truncates the text of the value to the number of runes by the argument,
+// e.g. {{ Title | truncate 20 }}. Truncated texts end with an ellipsis.
+func truncate(value interface{}, args ...string) interface{} {
+	if len(args) != 1 {
+		must(fmt.Errorf("truncate filter requires 1 argument: %d", len(args)))
+	}
+	n, err := strconv.Atoi(args[0])
+	must(err)
+	runes := []rune(fmt.Sprint(value))
+	if len(runes) <= n {
+		return string(runes)
+	}
+	return string(runes[:n]) + "…"
+}
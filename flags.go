@@ -0,0 +1,112 @@
+package vue
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Flags is a set of feature flags which renders subscribed components on change.
+// Flags may be static, loaded from remote json or set by an adapter of a flag service.
+// Flags are safe to set and load from any goroutine.
+type Flags struct {
+	mu      sync.RWMutex
+	flags   map[string]bool
+	renders []func()
+}
+
+// NewFlags creates new feature flags from the given static flags.
+func NewFlags(flags map[string]bool) *Flags {
+	f := &Flags{flags: make(map[string]bool, len(flags))}
+	for name, on := range flags {
+		f.flags[name] = on
+	}
+	return f
+}
+
+// FeatureFlags is the feature flags option for components.
+// Templates query flags by the flag function, e.g. v-if="flag('new-nav')".
+func FeatureFlags(flags *Flags) Option {
+	return func(comp *Comp) {
+		comp.flags = flags
+	}
+}
+
+// Flag returns whether the feature flag is on.
+// Unknown flags are off.
+func (f *Flags) Flag(name string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.flags[name]
+}
+
+// Set flips the feature flag then renders subscribed components.
+func (f *Flags) Set(name string, on bool) {
+	f.mu.Lock()
+	f.flags[name] = on
+	f.mu.Unlock()
+	f.render()
+}
+
+// Load loads flags from the remote json object of flag names to values
+// then renders subscribed components.
+func (f *Flags) Load(url string) error {
+	res, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to load flags: %s", res.Status)
+	}
+
+	flags := make(map[string]bool)
+	if err := json.NewDecoder(res.Body).Decode(&flags); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	for name, on := range flags {
+		f.flags[name] = on
+	}
+	f.mu.Unlock()
+	f.render()
+	return nil
+}
+
+// subscribe subscribes the render function to changes of flags.
+func (f *Flags) subscribe(render func()) {
+	f.mu.Lock()
+	f.renders = append(f.renders, render)
+	f.mu.Unlock()
+}
+
+// render renders subscribed components.
+func (f *Flags) render() {
+	invalidate()
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, render := range f.renders {
+		render()
+	}
+}
+
+// flag returns whether the feature flag of the component is on.
+// Components without feature flags have all flags off.
+func (vm *ViewModel) flag(name string) bool {
+	if vm.comp.flags == nil {
+		return false
+	}
+	return vm.comp.flags.Flag(name)
+}
+
+// flagName parses the name of the flag function, e.g. flag('new-nav').
+func flagName(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "flag(") || !strings.HasSuffix(value, ")") {
+		return "", false
+	}
+	name := strings.TrimSpace(value[len("flag(") : len(value)-1])
+	return strings.Trim(name, `'"`), true
+}
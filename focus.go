@@ -0,0 +1,51 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"syscall/js"
+)
+
+// viewState is the state of the view which is preserved across renders.
+type viewState struct {
+	active           js.Value
+	start, end       js.Value
+	scrollX, scrollY float64
+}
+
+// captureView captures the focus, selection and scroll of the view before rendering.
+func captureView() viewState {
+	window := js.Global()
+	active := document.Underlying().Get("activeElement")
+	state := viewState{
+		active:  active,
+		start:   js.Null(),
+		end:     js.Null(),
+		scrollX: window.Get("pageXOffset").Float(),
+		scrollY: window.Get("pageYOffset").Float(),
+	}
+	if active != js.Null() && active.Get("selectionStart") != js.Undefined() {
+		state.start = active.Get("selectionStart")
+		state.end = active.Get("selectionEnd")
+	}
+	return state
+}
+
+// restore restores the focus, selection and scroll of the view after rendering.
+// Focus is restored to the previously active element if it remains in the document.
+func (state viewState) restore() {
+	window := js.Global()
+	active := state.active
+	if active != js.Null() && active.Get("isConnected").Bool() {
+		if !active.Call("matches", ":focus").Bool() {
+			active.Call("focus", map[string]interface{}{"preventScroll": true})
+		}
+		if state.start != js.Null() && active.Get("selectionStart") != js.Null() {
+			active.Call("setSelectionRange", state.start, state.end)
+		}
+	}
+	if window.Get("pageXOffset").Float() != state.scrollX || window.Get("pageYOffset").Float() != state.scrollY {
+		window.Call("scrollTo", state.scrollX, state.scrollY)
+	}
+}
@@ -0,0 +1,165 @@
+// Package form generates forms from structs.
+package form
+
+import (
+	"fmt"
+	"github.com/norunners/vue"
+	"github.com/norunners/vue/validate"
+	"html"
+	"reflect"
+	"strings"
+)
+
+// tagName is the struct tag of form options, e.g. `form:"label=Email address,type=email"`.
+const tagName = "form"
+
+// Option is an option of form generation.
+type Option func(*generator)
+
+// generator generates a form template.
+type generator struct {
+	overrides map[string]string
+	labels    map[string]string
+	submit    string
+}
+
+// Override overrides the input template of the field, e.g. a textarea or a registered component.
+func Override(field, tmpl string) Option {
+	return func(g *generator) {
+		g.overrides[field] = tmpl
+	}
+}
+
+// Label labels the field, e.g. by a translated or runtime label.
+func Label(field, label string) Option {
+	return func(g *generator) {
+		g.labels[field] = label
+	}
+}
+
+// Submit adds a submit button to the form which calls the method on submit.
+// The method should prevent the default action of the event.
+func Submit(method string) Option {
+	return func(g *generator) {
+		g.submit = method
+	}
+}
+
+// Template generates a form template from the exported fields of the struct.
+// Each field has a label, an input bound by v-model and the first validation error of the field,
+// which is mapped by the FormErrors computed.
+// Inputs are typed by the type of the field: strings are text inputs, float64 are number inputs
+// and bools are checkboxes. The form tag sets the label and the input type,
+// e.g. `form:"label=Email address,type=email"`, and fields tagged `form:"-"` are skipped.
+func Template(data interface{}, options ...Option) string {
+	g := &generator{overrides: make(map[string]string), labels: make(map[string]string)}
+	for _, option := range options {
+		option(g)
+	}
+
+	typ := reflect.Indirect(reflect.ValueOf(data)).Type()
+	if typ.Kind() != reflect.Struct {
+		panic(fmt.Errorf("data is not of kind struct: %s", typ))
+	}
+
+	var b strings.Builder
+	if g.submit != "" {
+		fmt.Fprintf(&b, `<form class="form" v-on:submit.prevent="%s">`, g.submit)
+	} else {
+		b.WriteString(`<form class="form">`)
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tags := parseTag(field.Tag.Get(tagName))
+		if _, skip := tags["-"]; skip || field.PkgPath != "" {
+			continue
+		}
+		g.field(&b, field, tags)
+	}
+	if g.submit != "" {
+		b.WriteString(`<button type="submit">Submit</button>`)
+	}
+	b.WriteString(`</form>`)
+	return b.String()
+}
+
+// FormErrors is a computed which validates the data of the context by its validate tags.
+// The first error of each field is mapped by field name.
+// Invalid tags are mapped as the error of the form by the empty name.
+func FormErrors(context vue.Context) interface{} {
+	errs, err := validate.Struct(context.Data())
+	if err != nil {
+		return map[string]string{validate.Form: err.Error()}
+	}
+	first := make(map[string]string, len(errs))
+	for field := range errs {
+		first[field] = errs.First(field)
+	}
+	return first
+}
+
+// field generates the label, input and error of the field.
+func (g *generator) field(b *strings.Builder, field reflect.StructField, tags map[string]string) {
+	name := fieldName(field)
+	id := "form-" + name
+	label, ok := g.labels[name]
+	if !ok {
+		label, ok = tags["label"]
+	}
+	if !ok {
+		label = field.Name
+	}
+
+	b.WriteString(`<div class="form-field">`)
+	fmt.Fprintf(b, `<label for="%s">%s</label>`, id, html.EscapeString(label))
+	if tmpl, ok := g.overrides[name]; ok {
+		b.WriteString(tmpl)
+	} else {
+		g.input(b, field, name, id, tags["type"])
+	}
+	fmt.Fprintf(b, `<span class="form-error">{{ FormErrors.%s }}</span>`, name)
+	b.WriteString(`</div>`)
+}
+
+// input generates the input of the field by its type.
+func (g *generator) input(b *strings.Builder, field reflect.StructField, name, id, typ string) {
+	switch field.Type.Kind() {
+	case reflect.String:
+		if typ == "" {
+			typ = "text"
+		}
+		fmt.Fprintf(b, `<input id="%s" type="%s" v-model="%s">`, id, typ, name)
+	case reflect.Float64:
+		fmt.Fprintf(b, `<input id="%s" inputmode="decimal" v-model:number="%s">`, id, name)
+	case reflect.Bool:
+		fmt.Fprintf(b, `<input id="%s" type="checkbox" v-model="%s">`, id, name)
+	default:
+		panic(fmt.Errorf("form field %s is not of a supported type: %s", field.Name, field.Type))
+	}
+}
+
+// parseTag parses the options of the form tag.
+func parseTag(tag string) map[string]string {
+	tags := make(map[string]string)
+	if tag == "" {
+		return tags
+	}
+	for _, option := range strings.Split(tag, ",") {
+		parts := strings.SplitN(option, "=", 2)
+		if len(parts) == 1 {
+			tags[parts[0]] = ""
+		} else {
+			tags[parts[0]] = parts[1]
+		}
+	}
+	return tags
+}
+
+// fieldName returns the name of the struct field by the vue tag.
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("vue"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
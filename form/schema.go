@@ -0,0 +1,142 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Schema is a JSON Schema document of an object, e.g. defined by a backend for admin tools.
+// The supported keywords are title, properties and required,
+// and properties of type string, number, integer and boolean.
+type Schema struct {
+	Title      string               `json:"title"`
+	Type       string               `json:"type"`
+	Properties map[string]*Property `json:"properties"`
+	Required   []string             `json:"required"`
+}
+
+// Property is a property of a schema.
+// The supported keywords are type, title, format, minLength, maxLength, minimum and maximum.
+type Property struct {
+	Type      string   `json:"type"`
+	Title     string   `json:"title"`
+	Format    string   `json:"format"`
+	MinLength *int     `json:"minLength"`
+	MaxLength *int     `json:"maxLength"`
+	Minimum   *float64 `json:"minimum"`
+	Maximum   *float64 `json:"maximum"`
+}
+
+// LoadSchema loads the schema from the remote json document.
+func LoadSchema(url string) (*Schema, error) {
+	res, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to load schema: %s", res.Status)
+	}
+
+	schema := &Schema{}
+	if err := json.NewDecoder(res.Body).Decode(schema); err != nil {
+		return nil, err
+	}
+	if schema.Type != "object" {
+		return nil, fmt.Errorf("schema is not of type object: %s", schema.Type)
+	}
+	return schema, nil
+}
+
+// Data creates a pointer to a new struct of the properties for the data option.
+// Fields are named by the property names in templates and json, and are validated by the schema,
+// so the data is submitted by json encoding and validated by the FormErrors computed.
+func (schema *Schema) Data() interface{} {
+	names := schema.names()
+	fields := make([]reflect.StructField, 0, len(names))
+	for i, name := range names {
+		prop := schema.Properties[name]
+		tag := fmt.Sprintf(`vue:%q json:%q`, name, name)
+		if rules := schema.rules(name, prop); rules != "" {
+			tag += fmt.Sprintf(` validate:%q`, rules)
+		}
+		if prop.Format == "email" {
+			tag += ` form:"type=email"`
+		}
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Field%d", i),
+			Type: prop.kind(),
+			Tag:  reflect.StructTag(tag),
+		})
+	}
+	return reflect.New(reflect.StructOf(fields)).Interface()
+}
+
+// Template generates a form template from the schema for the data of the schema.
+// Fields are labeled by the titles of the properties, otherwise by the property names.
+func (schema *Schema) Template(data interface{}, options ...Option) string {
+	for _, name := range schema.names() {
+		label := schema.Properties[name].Title
+		if label == "" {
+			label = name
+		}
+		options = append([]Option{Label(name, label)}, options...)
+	}
+	return Template(data, options...)
+}
+
+// names returns the property names in sorted order.
+func (schema *Schema) names() []string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// rules returns the validation rules of the property.
+func (schema *Schema) rules(name string, prop *Property) string {
+	var rules []string
+	for _, required := range schema.Required {
+		if required == name {
+			rules = append(rules, "required")
+		}
+	}
+	if prop.MinLength != nil {
+		rules = append(rules, "min="+strconv.Itoa(*prop.MinLength))
+	}
+	if prop.MaxLength != nil {
+		rules = append(rules, "max="+strconv.Itoa(*prop.MaxLength))
+	}
+	if prop.Minimum != nil {
+		rules = append(rules, "min="+strconv.FormatFloat(*prop.Minimum, 'f', -1, 64))
+	}
+	if prop.Maximum != nil {
+		rules = append(rules, "max="+strconv.FormatFloat(*prop.Maximum, 'f', -1, 64))
+	}
+	if prop.Format == "email" {
+		rules = append(rules, "email")
+	}
+	return strings.Join(rules, ",")
+}
+
+// kind returns the Go type of the property.
+// Integers are numbers since number inputs bind float64.
+func (prop *Property) kind() reflect.Type {
+	switch prop.Type {
+	case "string":
+		return reflect.TypeOf("")
+	case "number", "integer":
+		return reflect.TypeOf(float64(0))
+	case "boolean":
+		return reflect.TypeOf(false)
+	default:
+		panic(fmt.Errorf("unsupported schema type: %s", prop.Type))
+	}
+}
@@ -0,0 +1,72 @@
+package vue
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Formats of the vue model attribute.
+const (
+	formatNumber   = "number"
+	formatCurrency = "currency"
+	formatChecked  = "checked"
+)
+
+// Attributes of formatted models.
+const (
+	modelFormat  = "model-format"
+	currencyAttr = "currency"
+)
+
+// defaultCurrency is the currency of currency models without a currency attribute.
+const defaultCurrency = "USD"
+
+// defaultLayout is the layout of the date filter without a layout argument.
+const defaultLayout = "Jan 2, 2006"
+
+// date is the filter which formats times by the layout argument, e.g. {{ CreatedAt | date "Jan 2" }}.
+func date(value interface{}, args ...string) interface{} {
+	layout := defaultLayout
+	if len(args) > 0 {
+		layout = args[0]
+	}
+	switch t := value.(type) {
+	case time.Time:
+		return t.Format(layout)
+	case *time.Time:
+		if t == nil {
+			return ""
+		}
+		return t.Format(layout)
+	default:
+		must(fmt.Errorf("date filter requires type time.Time: %T", value))
+		return nil
+	}
+}
+
+// currency is the filter which formats amounts in the locale of the user.
+// The optional argument is the currency code, e.g. {{ Price | currency "EUR" }}, which is USD by default.
+func currency(value interface{}, args ...string) interface{} {
+	code := defaultCurrency
+	if len(args) > 0 {
+		code = args[0]
+	}
+	return formatLocale(toFloat(value), formatCurrency, code)
+}
+
+// toFloat converts the number to float64.
+func toFloat(value interface{}) float64 {
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		return val.Float()
+	default:
+		must(fmt.Errorf("value is not a number: %T", value))
+		return 0
+	}
+}
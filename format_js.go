@@ -0,0 +1,69 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall/js"
+)
+
+// locale returns the locale of the user.
+func locale() string {
+	return js.Global().Get("navigator").Get("language").String()
+}
+
+// numberFormat creates a number format of the locale of the user.
+// Currency formats use the currency code, e.g. USD or EUR.
+func numberFormat(format, currency string) js.Value {
+	options := js.Global().Get("Object").New()
+	if format == formatCurrency {
+		options.Set("style", "currency")
+		options.Set("currency", currency)
+	} else {
+		options.Set("maximumFractionDigits", 20)
+	}
+	return js.Global().Get("Intl").Get("NumberFormat").New(locale(), options)
+}
+
+// number is the filter which formats numbers with group separators in the locale of the user.
+// The optional argument is the precision, e.g. {{ Total | number 2 }}.
+func number(value interface{}, args ...string) interface{} {
+	options := js.Global().Get("Object").New()
+	options.Set("maximumFractionDigits", 20)
+	if len(args) > 0 {
+		precision, err := strconv.Atoi(args[0])
+		must(err)
+		options.Set("minimumFractionDigits", precision)
+		options.Set("maximumFractionDigits", precision)
+	}
+	return js.Global().Get("Intl").Get("NumberFormat").New(locale(), options).Call("format", toFloat(value)).String()
+}
+
+// formatLocale formats the number for display in the locale of the user.
+func formatLocale(value float64, format, currency string) string {
+	return numberFormat(format, currency).Call("format", value).String()
+}
+
+// parseLocale parses the number displayed in the locale of the user.
+// Group separators and currency symbols are ignored.
+func parseLocale(text, format string) (float64, error) {
+	decimal := strings.Trim(numberFormat(formatNumber, "").Call("format", 1.5).String(), "15")
+
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9', r == '-':
+			b.WriteRune(r)
+		case string(r) == decimal:
+			b.WriteRune('.')
+		}
+	}
+	value, err := strconv.ParseFloat(b.String(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", format, text)
+	}
+	return value, nil
+}
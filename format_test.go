@@ -0,0 +1,64 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"testing"
+)
+
+func TestFormatLocale(t *testing.T) {
+	tests := []struct {
+		value    float64
+		format   string
+		currency string
+		want     string
+	}{
+		{1234.5, formatCurrency, "USD", "$1,234.50"},
+		{-1234.5, formatCurrency, "USD", "-$1,234.50"},
+		{1234567.891, formatCurrency, "EUR", "€1,234,567.89"},
+		{1234.5, formatCurrency, "JPY", "¥1,235"},
+		{1234.5, formatCurrency, "CHF", "CHF 1,234.50"},
+		{0, formatCurrency, "USD", "$0.00"},
+		{1234.5, formatNumber, "", "1,234.5"},
+		{-123456, formatNumber, "", "-123,456"},
+		{999, formatNumber, "", "999"},
+	}
+	for _, test := range tests {
+		if got := formatLocale(test.value, test.format, test.currency); got != test.want {
+			t.Errorf("formatLocale(%v, %s, %s) = %q, want %q", test.value, test.format, test.currency, got, test.want)
+		}
+	}
+}
+
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		args  []string
+		want  string
+	}{
+		{1234.5, []string{"2"}, "1,234.50"},
+		{1234567, nil, "1,234,567"},
+		{0.25, nil, "0.25"},
+		{-1000.125, []string{"1"}, "-1,000.1"},
+	}
+	for _, test := range tests {
+		if got := number(test.value, test.args...); got != test.want {
+			t.Errorf("number(%v, %v) = %q, want %q", test.value, test.args, got, test.want)
+		}
+	}
+}
+
+func TestCurrencyFilter(t *testing.T) {
+	comp := Component(
+		Template(`<p>{{ Price | currency }} {{ Price | currency "EUR" }}</p>`),
+		Data(&struct{ Price float64 }{Price: 1234.5}),
+	)
+	html, err := RenderToString(comp, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<p>$1,234.50 €1,234.50</p>"; html != want {
+		t.Errorf("got %s, want %s", html, want)
+	}
+}
@@ -0,0 +1,121 @@
+package vue
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// formatters are the registered formatters of interpolated values by type.
+var formatters = make(map[reflect.Type]func(interface{}) string)
+
+// Formatter registers the formatter of interpolated values globally by the type of its argument,
+// e.g. vue.Formatter(func(t time.Time) string { return t.Format("Jan 2, 2006") }).
+// The formatter must be a function of one argument which returns a string.
+// Without a formatter, values are interpolated by fmt.Stringer or encoding.TextMarshaler when implemented.
+func Formatter(format interface{}) {
+	fn := reflect.ValueOf(format)
+	typ := fn.Type()
+	if typ.Kind() != reflect.Func || typ.NumIn() != 1 || typ.NumOut() != 1 || typ.Out(0).Kind() != reflect.String {
+		must(fmt.Errorf("formatter is not a function of one argument which returns a string: %T", format))
+	}
+	formatters[typ.In(0)] = func(value interface{}) string {
+		return fn.Call([]reflect.Value{reflect.ValueOf(value)})[0].String()
+	}
+}
+
+// formatted is a value which is interpolated by its formatted text.
+// The text is formatted once interpolated, so values which are not interpolated are not formatted.
+type formatted struct {
+	value  interface{}
+	format func(value interface{}) (string, error)
+}
+
+// String returns the formatted text, or the value formatted by fmt if formatting fails.
+func (f formatted) String() string {
+	text, err := f.format(f.value)
+	if err != nil {
+		return fmt.Sprint(f.value)
+	}
+	return text
+}
+
+// MarshalJSON encodes the formatted text, which is the dependency of the regions which read the value.
+// Errors of formatting keep the value from being encoded, so the regions are never reused.
+func (f formatted) MarshalJSON() ([]byte, error) {
+	text, err := f.format(f.value)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(text)
+}
+
+// unformat returns the underlying value of formatted values.
+func unformat(value interface{}) interface{} {
+	if f, ok := value.(formatted); ok {
+		return f.value
+	}
+	return value
+}
+
+// formatValue returns the value to interpolate.
+// Registered formatters take precedence over fmt.Stringer, then encoding.TextMarshaler.
+// Returns false for values which are not formatted.
+func formatValue(val reflect.Value) (interface{}, bool) {
+	value := val.Interface()
+	if format, ok := formatters[val.Type()]; ok {
+		return formatted{value: value, format: func(value interface{}) (string, error) {
+			return format(value), nil
+		}}, true
+	}
+	if _, ok := value.(fmt.Stringer); ok {
+		return value, true
+	}
+	if _, ok := value.(encoding.TextMarshaler); ok {
+		return formatted{value: value, format: marshalText}, true
+	}
+	return nil, false
+}
+
+// marshalText formats the value by encoding.TextMarshaler.
+func marshalText(value interface{}) (string, error) {
+	text, err := value.(encoding.TextMarshaler).MarshalText()
+	return string(text), err
+}
+
+// formatFields replaces the mapped fields of the struct value with their formatted values.
+// Nested structs and slices of structs are formatted recursively.
+func formatFields(val reflect.Value, m map[string]interface{}) {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name := fieldName(field)
+		mapped, ok := m[name]
+		if field.PkgPath != "" || !ok {
+			continue
+		}
+		m[name] = formatMapped(val.Field(i), mapped)
+	}
+}
+
+// formatMapped returns the formatted value of the mapped value.
+func formatMapped(val reflect.Value, mapped interface{}) interface{} {
+	if value, ok := formatValue(val); ok {
+		return value
+	}
+	val = reflect.Indirect(val)
+	switch val.Kind() {
+	case reflect.Struct:
+		if nested, ok := mapped.(map[string]interface{}); ok {
+			formatFields(val, nested)
+		}
+	case reflect.Slice, reflect.Array:
+		if items, ok := mapped.([]interface{}); ok && len(items) == val.Len() {
+			for i := range items {
+				items[i] = formatMapped(val.Index(i), items[i])
+			}
+		}
+	}
+	return mapped
+}
@@ -0,0 +1,35 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+)
+
+const fullscreen = "fullscreen"
+
+// fullscreenField is the data field of the fullscreen state.
+const fullscreenField = "Fullscreen"
+
+// Fullscreen is the fullscreen option for root components.
+// The fullscreen state of the document is mapped to the Fullscreen data field unless data has the same field.
+// The component renders when the document enters or exits fullscreen.
+func Fullscreen() Option {
+	return func(comp *Comp) {
+		comp.fullscreen = true
+	}
+}
+
+// fullscreenData maps the fullscreen state to data.
+func (vm *ViewModel) fullscreenData() {
+	if !vm.comp.fullscreen {
+		return
+	}
+	if _, ok := vm.data[fullscreenField]; !ok {
+		vm.data[fullscreenField] = isFullscreen()
+	}
+}
+
+// executeAttrFullscreen executes the vue fullscreen attribute.
+func (tmpl *template) executeAttrFullscreen(node *html.Node, selector string) {
+	node.Attr = append(node.Attr, html.Attribute{Key: fullscreen, Val: selector})
+	tmpl.comp.callback.addEventListener(vFullscreen, "click")
+}
@@ -0,0 +1,67 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"github.com/gowasm/go-js-dom"
+	"syscall/js"
+)
+
+// RequestFullscreen requests the query selected element to be displayed in fullscreen.
+// The request must be made from a user input, e.g. a click method.
+func RequestFullscreen(selector string) {
+	el := document.QuerySelector(selector)
+	if el == nil {
+		return
+	}
+	el.Underlying().Call("requestFullscreen")
+}
+
+// ExitFullscreen exits fullscreen of the document.
+func ExitFullscreen() {
+	if isFullscreen() {
+		document.Underlying().Call("exitFullscreen")
+	}
+}
+
+// watchFullscreen watches the fullscreen state of the document.
+func (vm *ViewModel) watchFullscreen() {
+	if !vm.comp.fullscreen || vm.comp.isSub {
+		return
+	}
+
+	vm.listen(document, "fullscreenchange", func(dom.Event) {
+		defer vm.report()
+		vm.breadcrumb("fullscreen: %t", isFullscreen())
+		vm.render()
+	})
+}
+
+// vFullscreen is the vue fullscreen event callback.
+// The query selected element of the closest tagged element is toggled to fullscreen,
+// the root element is toggled without a selector.
+func (vm *ViewModel) vFullscreen(event dom.Event) {
+	defer vm.report()
+	for el := event.Target(); el != nil; el = el.ParentElement() {
+		selector, ok := el.Attributes()[fullscreen]
+		if !ok {
+			continue
+		}
+		switch {
+		case isFullscreen():
+			ExitFullscreen()
+		case selector == "":
+			vm.comp.el.Underlying().Call("requestFullscreen")
+		default:
+			RequestFullscreen(selector)
+		}
+		return
+	}
+}
+
+// isFullscreen determines if the document is displayed in fullscreen.
+func isFullscreen() bool {
+	el := document.Underlying().Get("fullscreenElement")
+	return el != js.Null() && el != js.Undefined()
+}
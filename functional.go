@@ -0,0 +1,11 @@
+package vue
+
+// Functional creates a functional component which renders html from its props.
+// Functional components have no view model, data, methods or event callbacks,
+// which makes them lightweight, e.g. to render items of long lists.
+// The props must not be modified by the render function.
+func Functional(render func(props map[string]interface{}) string, props ...string) *Comp {
+	comp := Component(Props(props...))
+	comp.functional = render
+	return comp
+}
@@ -0,0 +1,33 @@
+package vue
+
+// gamepadsField is the data field of the gamepad states.
+const gamepadsField = "Gamepads"
+
+// GamepadState is the state of a connected gamepad.
+// Buttons are pressed values from 0 to 1 and axes range from -1 to 1.
+type GamepadState struct {
+	Index   int
+	ID      string
+	Buttons []float64
+	Axes    []float64
+}
+
+// Gamepad is the gamepad option for root components.
+// Gamepads are polled each animation frame, the states of connected gamepads are mapped to
+// the Gamepads data field unless data has the same field, e.g. for games and simulators.
+// The component renders when the state of a gamepad changes.
+func Gamepad() Option {
+	return func(comp *Comp) {
+		comp.gamepad = true
+	}
+}
+
+// gamepadsData maps the gamepad states to data.
+func (vm *ViewModel) gamepadsData() {
+	if !vm.comp.gamepad {
+		return
+	}
+	if _, ok := vm.data[gamepadsField]; !ok {
+		vm.data[gamepadsField] = vm.gamepads
+	}
+}
@@ -0,0 +1,66 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"reflect"
+	"syscall/js"
+)
+
+// watchGamepads polls the gamepads on each animation frame until the root is unmounted.
+func (vm *ViewModel) watchGamepads() {
+	if !vm.comp.gamepad || vm.comp.isSub {
+		return
+	}
+	navigator := js.Global().Get("navigator")
+	if navigator.Get("getGamepads") == js.Undefined() {
+		return
+	}
+
+	var poll func()
+	poll = func() {
+		vm.mu.Lock()
+		unmounted := vm.unmounted
+		vm.mu.Unlock()
+		if unmounted {
+			return
+		}
+		defer requestAnimationFrame(poll)
+		defer vm.report()
+		gamepads := pollGamepads(navigator.Call("getGamepads"))
+		if !reflect.DeepEqual(gamepads, vm.gamepads) {
+			vm.gamepads = gamepads
+			vm.render()
+		}
+	}
+	requestAnimationFrame(poll)
+}
+
+// pollGamepads returns the states of the connected gamepads.
+func pollGamepads(gamepads js.Value) []GamepadState {
+	states := make([]GamepadState, 0)
+	for i := 0; i < gamepads.Length(); i++ {
+		gamepad := gamepads.Index(i)
+		if gamepad == js.Null() || gamepad == js.Undefined() || !gamepad.Get("connected").Bool() {
+			continue
+		}
+
+		buttons := gamepad.Get("buttons")
+		axes := gamepad.Get("axes")
+		state := GamepadState{
+			Index:   gamepad.Get("index").Int(),
+			ID:      gamepad.Get("id").String(),
+			Buttons: make([]float64, buttons.Length()),
+			Axes:    make([]float64, axes.Length()),
+		}
+		for j := range state.Buttons {
+			state.Buttons[j] = buttons.Index(j).Get("value").Float()
+		}
+		for j := range state.Axes {
+			state.Axes[j] = axes.Index(j).Float()
+		}
+		states = append(states, state)
+	}
+	return states
+}
@@ -0,0 +1,96 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"strings"
+)
+
+// headElement is the element which manages the head of the document from components,
+// e.g. <v-head><title>{{ Title }}</title><meta name="description" v-bind:content="Summary"></v-head>.
+// The title sets the title of the document, which is reverted once no component renders a title, e.g. on unmount.
+// Other children, e.g. meta and link tags, are rendered into the head after its existing children, which are removed likewise.
+// Heads of components rendered later take precedence, e.g. the title of the page of a route over the title of the layout.
+// Likewise meta tags of the same name or property and link tags of the same rel are rendered once,
+// and replace the existing tags of the head, e.g. the tags rendered by the server into a prerendered page.
+const headElement = "v-head"
+
+// headTarget is the selector of the target of the head element.
+const headTarget = "head"
+
+// newTitle creates a title element of the text.
+func newTitle(text string) *html.Node {
+	title := &html.Node{Type: html.ElementNode, Data: "title", DataAtom: atom.Title}
+	title.AppendChild(&html.Node{Type: html.TextNode, Data: text})
+	return title
+}
+
+// resourceRels are the rels of links which the head renders any number of, e.g. stylesheets.
+var resourceRels = map[string]struct{}{
+	"stylesheet": {}, "preload": {}, "prefetch": {}, "preconnect": {}, "dns-prefetch": {}, "modulepreload": {},
+}
+
+// headKey returns the key of the tag of the head by which the later of the tags takes precedence,
+// e.g. meta tags by name or property and link tags by rel.
+func headKey(tag string, attrs map[string]string) (string, bool) {
+	switch strings.ToLower(tag) {
+	case "meta":
+		if _, ok := attrs["charset"]; ok {
+			return "meta charset", true
+		}
+		for _, key := range []string{"name", "property", "http-equiv"} {
+			if val, ok := attrs[key]; ok {
+				return "meta " + key + "=" + val, true
+			}
+		}
+	case "link":
+		rel := strings.ToLower(attrs["rel"])
+		if _, ok := resourceRels[rel]; ok || rel == "" {
+			return "", false
+		}
+		return "link rel=" + rel + " hreflang=" + attrs["hreflang"], true
+	}
+	return "", false
+}
+
+// dedupeHead removes the tags from the children of the head which are followed by tags of the same key.
+// Returns the keys of the remaining tags.
+func dedupeHead(head *html.Node) map[string]struct{} {
+	last := make(map[string]*html.Node)
+	for _, child := range children(head) {
+		if child.Type != html.ElementNode {
+			continue
+		}
+		key, ok := headKey(child.Data, attrMap(child))
+		if !ok {
+			continue
+		}
+		if prev, ok := last[key]; ok {
+			head.RemoveChild(prev)
+		}
+		last[key] = child
+	}
+	keys := make(map[string]struct{}, len(last))
+	for key := range last {
+		keys[key] = struct{}{}
+	}
+	return keys
+}
+
+// headTitle removes the titles from the children of the head, returning the text of the last title.
+func headTitle(head *html.Node) (string, bool) {
+	var title string
+	var ok bool
+	for _, child := range children(head) {
+		if child.Type != html.ElementNode || child.Data != "title" {
+			continue
+		}
+		head.RemoveChild(child)
+		var b strings.Builder
+		for _, text := range children(child) {
+			b.WriteString(text.Data)
+		}
+		title, ok = b.String(), true
+	}
+	return title, ok
+}
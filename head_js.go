@@ -0,0 +1,99 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"github.com/gowasm/go-js-dom"
+	"golang.org/x/net/html"
+)
+
+// renderTitle sets the title of the document from the rendered head,
+// otherwise the title of the document before the head is restored.
+func (vm *ViewModel) renderTitle(teleported map[string]*html.Node) {
+	head, ok := teleported[headTarget]
+	if !ok {
+		vm.restoreTitle()
+		return
+	}
+	title, ok := headTitle(head)
+	if !ok {
+		vm.restoreTitle()
+		return
+	}
+	doc := document.Underlying()
+	if vm.title == nil {
+		restore := doc.Get("title").String()
+		vm.title = &restore
+	}
+	if doc.Get("title").String() != title {
+		doc.Set("title", title)
+	}
+}
+
+// restoreTitle restores the title of the document before the head.
+func (vm *ViewModel) restoreTitle() {
+	if vm.title == nil {
+		return
+	}
+	document.Underlying().Set("title", *vm.title)
+	vm.title = nil
+}
+
+// adoptHead dedupes the tags of the rendered head, then removes the existing tags of the document head of the same keys,
+// e.g. the tags rendered by the server into a prerendered page. The removed tags are restored once the root is unmounted.
+func (vm *ViewModel) adoptHead(teleported map[string]*html.Node) {
+	head, ok := teleported[headTarget]
+	if !ok {
+		return
+	}
+	keys := dedupeHead(head)
+	if len(keys) == 0 {
+		return
+	}
+	el := document.QuerySelector(headTarget)
+	if el == nil {
+		return
+	}
+	var owned []dom.Node
+	if target, ok := vm.teleports[headTarget]; ok {
+		for child := target.firstChild; child != nil; child = child.nextSibling {
+			owned = append(owned, child.node)
+		}
+	}
+	for _, node := range el.ChildNodes() {
+		child, ok := node.(dom.Element)
+		if !ok || isOwned(owned, child) {
+			continue
+		}
+		if key, ok := headKey(child.TagName(), child.Attributes()); ok {
+			if _, ok := keys[key]; ok {
+				el.RemoveChild(child)
+				vm.adopted = append(vm.adopted, child)
+			}
+		}
+	}
+}
+
+// restoreHead restores the tags of the document head which were removed by the rendered head.
+func (vm *ViewModel) restoreHead() {
+	if len(vm.adopted) == 0 {
+		return
+	}
+	if el := document.QuerySelector(headTarget); el != nil {
+		for _, node := range vm.adopted {
+			el.AppendChild(node)
+		}
+	}
+	vm.adopted = nil
+}
+
+// isOwned determines if the node is one of the owned nodes, which are siblings, so only contain themselves.
+func isOwned(owned []dom.Node, node dom.Node) bool {
+	for _, n := range owned {
+		if n != nil && n.Contains(node) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"strconv"
+	"strings"
+)
+
+// Outside of the browser, root view models render headlessly into a tree of html nodes, e.g. for unit tests under go test.
+// Renders are immediate instead of scheduled on animation frames, so the tree is rendered once methods return.
+// Renders requested while rendering, e.g. by hooks or updates of goroutines, render again once the render completes.
+// Teleports and heads are not rendered, since there is no document.
+
+// browser is the state of the headless view model.
+type browser struct {
+	headless  bool
+	rendering bool
+	node      *html.Node
+	event     *Event
+}
+
+// Headless creates a root view model which renders an instance of the component with the data headlessly,
+// e.g. to assert the rendered output of templates and directives in unit tests:
+//
+//	vm := vue.Headless(todo, &Data{Todos: []string{"milk"}})
+//	if got := vm.HTML(); got != "<ul><li>milk</li></ul>" {
+//		t.Errorf("got %s", got)
+//	}
+//
+// The data replaces the data option of the component unless nil.
+// Errors of the first render panic unless handled by the error options.
+func Headless(comp *Comp, data interface{}) *ViewModel {
+	root := comp.clone()
+	root.callback = nil
+	if data != nil {
+		root.data = data
+	}
+	return newViewModel(root)
+}
+
+// Node returns the rendered tree of the headless view model.
+// The node is a placeholder of which the children are the rendered root nodes.
+func (vm *ViewModel) Node() *html.Node {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	if vm.node == nil {
+		return &html.Node{Type: html.ElementNode}
+	}
+	return vm.node
+}
+
+// HTML returns the rendered html of the headless view model.
+func (vm *ViewModel) HTML() string {
+	var b strings.Builder
+	for _, child := range children(vm.Node()) {
+		must(html.Render(&b, child))
+	}
+	return b.String()
+}
+
+// EventInit is the payload of events dispatched headlessly, e.g. the value of inputs or the key of keyboard events.
+type EventInit struct {
+	Value   string
+	Checked bool
+	Key     string
+	ClientX float64
+	ClientY float64
+}
+
+// NewEvent creates the event of the type with the payload, e.g. to dispatch to headless view models in tests.
+func NewEvent(typ string, init EventInit) *Event {
+	return &Event{typ: typ, init: init}
+}
+
+// Dispatch dispatches the event to the element of the rendered tree like the browser, then renders.
+// The method bound to the type of the event by the on attribute is called, during which the context returns the event.
+// Otherwise, the field bound by the model attribute is set to the value of the event,
+// the checked state for checkboxes or the number of the value for formatted fields.
+// Panics if the element has no method or field bound to the type of the event.
+func (vm *ViewModel) Dispatch(target *html.Node, event *Event) {
+	typ := event.typ
+	attrs := attrMap(target)
+	name, ok := attrs[typ]
+	if !ok {
+		must(fmt.Errorf("element has no method or field bound to the event: %s", typ))
+	}
+	event.target = target
+
+	vm.breadcrumb("event: %s %s", typ, name)
+	if _, _, ok := vm.method(name); ok {
+		vm.event = event
+		defer func() {
+			vm.event = nil
+		}()
+		vm.Call(name)
+		return
+	}
+	comp, field := vm.model(name)
+	if _, ok := comp.dataField(field); !ok {
+		if _, ok := comp.propName(field); !ok {
+			must(fmt.Errorf("unknown method or field: %s", field))
+		}
+	}
+
+	var value interface{} = event.init.Value
+	if isContentEditable(attrs) {
+		value = sanitize(event.init.Value)
+	}
+	switch format, ok := attrs[modelFormat]; {
+	case !ok:
+	case format == formatChecked:
+		value = event.init.Checked
+	default:
+		number, err := strconv.ParseFloat(event.init.Value, 64)
+		if err != nil {
+			must(fmt.Errorf("invalid number of the field: %s: %v", name, err))
+		}
+		value = number
+	}
+	comp.setModel(field, value)
+	vm.render()
+}
+
+// watch marks the root view model to render headlessly.
+func (vm *ViewModel) watch() {
+	vm.headless = !vm.comp.isSub
+}
+
+// render renders the headless view model immediately.
+// Subcomponents use the callback to render the root.
+// View models which only render on the server, e.g. by RenderToString, do not render again.
+func (vm *ViewModel) render() {
+	if vm.comp.isSub {
+		if vm.executed {
+			vm.comp.callback.render()
+		}
+		return
+	}
+
+	vm.mu.Lock()
+	if vm.scheduled || vm.unmounted || !vm.headless {
+		vm.mu.Unlock()
+		return
+	}
+	if vm.rendering {
+		vm.pending = true
+		vm.mu.Unlock()
+		return
+	}
+	vm.scheduled = true
+	vm.mu.Unlock()
+	vm.flush()
+}
+
+// flush renders the prepared data into the tree immediately.
+// Queued updates are applied before rendering, then pending renders render again.
+func (vm *ViewModel) flush() {
+	defer vm.report()
+	vm.mu.Lock()
+	vm.scheduled = false
+	if vm.unmounted || !vm.headless {
+		vm.mu.Unlock()
+		return
+	}
+	vm.rendering = true
+	vm.mu.Unlock()
+	defer func() {
+		vm.mu.Lock()
+		vm.rendering, vm.pending = false, false
+		vm.mu.Unlock()
+	}()
+
+	for {
+		vm.renders++
+		vm.applyUpdates()
+		vm.mapData()
+		node := vm.tmpl.execute(vm, vm.data)
+		vm.unmountSubs()
+		teleport(node, make(map[string]*html.Node))
+		vm.mu.Lock()
+		vm.node = node
+		vm.mu.Unlock()
+		vm.tick()
+
+		vm.mu.Lock()
+		pending := vm.pending && !vm.unmounted
+		vm.pending = false
+		vm.mu.Unlock()
+		if !pending {
+			return
+		}
+	}
+}
+
+// renderFallback renders the fallback template with the error into the tree.
+func (vm *ViewModel) renderFallback(err error) {
+	node := vm.fallbackNode(err)
+	vm.mu.Lock()
+	vm.node = node
+	vm.mu.Unlock()
+}
+
+// clear removes the rendered tree of the unmounted root.
+func (vm *ViewModel) clear() {
+	vm.mu.Lock()
+	vm.node = nil
+	vm.mu.Unlock()
+}
@@ -0,0 +1,18 @@
+package vue
+
+// stateAttr is the attribute of the element of the el option to the data rendered by the server as json,
+// which hydrating roots restore before their first render.
+const stateAttr = "data-vue-state"
+
+// Hydrate is the hydration option for root components.
+// The root adopts the markup rendered by the server within the element of the el option,
+// instead of rendering it again, e.g. the page is visible while the wasm of the application starts.
+// Event listeners and bindings are attached to the existing elements by the first render,
+// which patches only the mismatches of the markup, which are warned in the console.
+// The data fields of the state attribute, data-vue-state, are restored before the first render,
+// so the markup rendered from the same data matches. The server renders the state by RenderState.
+func Hydrate() Option {
+	return func(comp *Comp) {
+		comp.hydrate = true
+	}
+}
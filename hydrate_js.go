@@ -0,0 +1,91 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"encoding/json"
+	"fmt"
+	"golang.org/x/net/html"
+	"strings"
+)
+
+// hydrateState restores the data fields of the state attribute of the element, then removes the attribute.
+func (vm *ViewModel) hydrateState() {
+	el := vm.comp.el
+	if el == nil || !el.HasAttribute(stateAttr) {
+		return
+	}
+	text := el.GetAttribute(stateAttr)
+	el.RemoveAttribute(stateAttr)
+
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(text), &state); err != nil {
+		vm.comp.warn(fmt.Errorf("hydration state is invalid: %v", err))
+		return
+	}
+	for field, value := range state {
+		val, ok := vm.comp.dataField(field)
+		if !ok || !val.CanAddr() {
+			continue
+		}
+		if err := json.Unmarshal(value, val.Addr().Interface()); err != nil {
+			vm.comp.warn(fmt.Errorf("hydration field is invalid: %s: %v", field, err))
+		}
+	}
+}
+
+// hydrateMismatch warns of the first mismatch between the markup of the server and the executed node.
+func (vm *ViewModel) hydrateMismatch(node *html.Node) {
+	if path, ok := vm.vnode.mismatch(node); ok {
+		vm.comp.warn(fmt.Errorf("hydration mismatch: %s", path))
+	}
+}
+
+// mismatch recursively compares the virtual node to the html node,
+// returning the path of the first mismatching node.
+// Text of whitespace only is ignored, e.g. the indentation of the markup of the server.
+func (dst *vnode) mismatch(src *html.Node) (string, bool) {
+	dstChild, srcChild := dst.firstChild, src.FirstChild
+	for {
+		for dstChild != nil && dstChild.typ == html.TextNode && strings.TrimSpace(dstChild.data) == "" {
+			dstChild = dstChild.nextSibling
+		}
+		for srcChild != nil && srcChild.Type == html.TextNode && strings.TrimSpace(srcChild.Data) == "" {
+			srcChild = srcChild.NextSibling
+		}
+		switch {
+		case dstChild == nil && srcChild == nil:
+			return "", false
+		case dstChild == nil || srcChild == nil:
+			return nodePath(src), true
+		case dstChild.typ != srcChild.Type:
+			return nodePath(srcChild), true
+		case srcChild.Type == html.TextNode:
+			if strings.TrimSpace(dstChild.data) != strings.TrimSpace(srcChild.Data) {
+				return nodePath(srcChild), true
+			}
+		default:
+			if dstChild.data != srcChild.Data || !sameAttrs(dstChild.attrs, attrMap(srcChild)) {
+				return nodePath(srcChild), true
+			}
+			if path, ok := dstChild.mismatch(srcChild); ok {
+				return path, true
+			}
+		}
+		dstChild, srcChild = dstChild.nextSibling, srcChild.NextSibling
+	}
+}
+
+// sameAttrs determines if the attributes are the same.
+func sameAttrs(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, val := range a {
+		if other, ok := b[key]; !ok || other != val {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type hydrateData struct {
+	Title string
+	Price float64
+	Count int
+	Items []string
+}
+
+// Inc increments the count.
+func (data *hydrateData) Inc(context Context) {
+	data.Count++
+}
+
+func TestHydrateRoundTrip(t *testing.T) {
+	comp := Component(
+		Template(`<div>
+			<h1>{{ Title }}</h1>
+			<p class="price">{{ Price | currency }} {{ Count | number }}</p>
+			<ul><li v-for="item in Items">{{ item }}</li></ul>
+			<button v-on:click="Inc">+</button>
+		</div>`),
+		Data(&hydrateData{}),
+		Methods(&hydrateData{}),
+	)
+	page := &hydrateData{Title: `Tom & "Jerry" <3`, Price: 1234.5, Count: 12345, Items: []string{"a < b", "c & d"}}
+	app, err := RenderToString(comp, page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`Tom &amp; &#34;Jerry&#34; &lt;3`, `$1,234.50 12,345`, `c &amp; d`} {
+		if !strings.Contains(app, want) {
+			t.Errorf("server markup does not contain %s: %s", want, app)
+		}
+	}
+	state, err := RenderState(comp, page)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The browser restores the state into the data of the root before its first render.
+	data := &hydrateData{}
+	if err := json.Unmarshal([]byte(state), data); err != nil {
+		t.Fatal(err)
+	}
+	vm := Headless(comp, data)
+	if got := vm.HTML(); got != app {
+		t.Errorf("hydrated markup mismatch:\ngot  %s\nwant %s", got, app)
+	}
+}
@@ -0,0 +1,102 @@
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"sort"
+)
+
+// Attrs are the attributes of an element created by the render function api.
+// Vue attributes are executed like in templates, e.g. "v-on:click".
+type Attrs map[string]string
+
+// Node is a node created by the render function api.
+type Node struct {
+	node *html.Node
+}
+
+// Render is the render function option for components.
+// The render function is an alternative to the template option,
+// which allows components to be written in type checked Go.
+func Render(render func(context Context) *Node) Option {
+	return func(comp *Comp) {
+		comp.render = render
+	}
+}
+
+// H creates an element node by tag with the attributes and children.
+// Children are nodes, slices of nodes or strings, which create text nodes.
+func H(tag string, attrs Attrs, children ...interface{}) *Node {
+	node := &html.Node{Type: html.ElementNode, Data: tag, DataAtom: atom.Lookup([]byte(tag))}
+
+	// Sort attributes to render deterministically.
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		node.Attr = append(node.Attr, html.Attribute{Key: key, Val: attrs[key]})
+	}
+
+	appendChildren(node, children)
+	return &Node{node: node}
+}
+
+// Fragment creates a fragment of the children, which render in place without a wrapper element,
+// e.g. to render multiple root elements or the cells of a table row.
+func Fragment(children ...interface{}) *Node {
+	node := &html.Node{Type: html.ElementNode}
+	appendChildren(node, children)
+	return &Node{node: node}
+}
+
+// appendChildren appends the children to the node.
+// The children of fragments are appended in place of the fragment.
+func appendChildren(node *html.Node, children []interface{}) {
+	for _, child := range children {
+		switch child := child.(type) {
+		case *Node:
+			if child != nil {
+				appendNode(node, child.node)
+			}
+		case []*Node:
+			for _, c := range child {
+				if c != nil {
+					appendNode(node, c.node)
+				}
+			}
+		case string:
+			node.AppendChild(Text(child).node)
+		default:
+			must(fmt.Errorf("unknown child type: %T", child))
+		}
+	}
+}
+
+// appendNode appends the child to the node, or the children of fragments.
+func appendNode(node, child *html.Node) {
+	if child.Type != html.ElementNode || child.Data != "" {
+		node.AppendChild(child)
+		return
+	}
+	for _, c := range children(child) {
+		child.RemoveChild(c)
+		node.AppendChild(c)
+	}
+}
+
+// Text creates a text node.
+func Text(text string) *Node {
+	return &Node{node: &html.Node{Type: html.TextNode, Data: text}}
+}
+
+// renderNode renders the component into a placeholder node by the render function.
+func (comp *Comp) renderNode(context Context) *html.Node {
+	node := &html.Node{Type: html.ElementNode}
+	if root := comp.render(context); root != nil {
+		appendNode(node, root.node)
+	}
+	return node
+}
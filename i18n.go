@@ -0,0 +1,189 @@
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// messages are the registered messages by locale then by key.
+var messages = make(map[string]map[string]string)
+
+// currentLocale is the locale of translations, which is the locale of the user by default.
+var currentLocale struct {
+	sync.RWMutex
+	locale string
+}
+
+// translateTag matches interpolations of translations with optional params,
+// e.g. {{ $t("welcome") }}, {{ $t("greeting", User.Name, "!") }} or {{ $tc("items", Count, owner=User.Name) }}.
+var translateTag = regexp.MustCompile(`\{\{\s*\$(tc?)\(\s*("(?:[^"\\]|\\.)*")\s*((?:,\s*(?:\w+\s*=\s*)?(?:"(?:[^"\\]|\\.)*"|[^,(){}"=]+?)\s*)*)\)\s*\}\}`)
+
+// translateParam matches the params of translations, which are optionally named.
+var translateParam = regexp.MustCompile(`(?:(\w+)\s*=\s*)?("(?:[^"\\]|\\.)*"|[^,\s]+)`)
+
+// pluralRules are the registered plural rules by locale.
+var pluralRules = make(map[string]func(count, forms int) int)
+
+// Messages registers the messages of the locale by key globally, e.g. Messages("fr", map[string]string{"welcome": "Bienvenue"}).
+// Messages are interpolated with params by position, by the dotted path of the param or by the name of the param,
+// e.g. "Hello, {0}", "Hello, {User.Name}" or "Hello, {owner}" of $t("hello", owner=User.Name).
+// Plural messages separate their forms by pipes, e.g. "no items | one item | {count} items",
+// which are chosen by the count of $tc("items", Count) and interpolated with the count as {count} and {n}.
+// Messages are text, so markup of messages and params is displayed literally rather than parsed,
+// e.g. a param of <b> is rendered as &lt;b&gt; on the server and displayed as <b> in the browser.
+func Messages(locale string, msgs map[string]string) {
+	if _, ok := messages[locale]; !ok {
+		messages[locale] = make(map[string]string)
+	}
+	for key, msg := range msgs {
+		messages[locale][key] = msg
+	}
+}
+
+// Locale returns the locale of translations.
+func (vm *ViewModel) Locale() string {
+	return translationLocale()
+}
+
+// translationLocale returns the set locale or the locale of the user.
+func translationLocale() string {
+	currentLocale.RLock()
+	defer currentLocale.RUnlock()
+	if currentLocale.locale == "" {
+		return locale()
+	}
+	return currentLocale.locale
+}
+
+// SetLocale sets the locale of translations then renders all root view models.
+func (vm *ViewModel) SetLocale(locale string) {
+	vm.breadcrumb("locale: %s", locale)
+	setLocale(locale)
+	vm.render()
+}
+
+// setLocale sets the locale of translations then renders all root view models.
+func setLocale(locale string) {
+	currentLocale.Lock()
+	currentLocale.locale = locale
+	currentLocale.Unlock()
+	invalidate()
+	for _, root := range rootViewModels() {
+		root.render()
+	}
+}
+
+// translate returns the message of the key in the locale.
+// The language of regional locales is the fallback, e.g. fr for fr-CA, then the key itself.
+// Messages of the fallback locale are translated while the catalog of the locale loads.
+func translate(locale, key string) string {
+	locale = catalogLocale(locale)
+	if msg, ok := messages[locale][key]; ok {
+		return msg
+	}
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		if msg, ok := messages[locale[:i]][key]; ok {
+			return msg
+		}
+	}
+	return key
+}
+
+// Plural registers the plural rule of the locale globally.
+// The rule chooses the index of the form by the count and the number of forms of plural messages,
+// e.g. for languages with distinct forms for few and many.
+func Plural(locale string, rule func(count, forms int) int) {
+	pluralRules[locale] = rule
+}
+
+// plural chooses the index of the form by the count.
+// Messages of 3 forms choose by zero, one then many, otherwise by one then many.
+func plural(count, forms int) int {
+	switch {
+	case forms >= 3 && count == 0:
+		return 0
+	case count == 1 || count == -1:
+		return forms - 2
+	default:
+		return forms - 1
+	}
+}
+
+// pluralize chooses the form of the plural message by the count in the locale.
+// The plural rule of the language of regional locales is the fallback.
+func pluralize(locale, msg string, count int) string {
+	forms := strings.Split(msg, "|")
+	if len(forms) == 1 {
+		return msg
+	}
+	rule, ok := pluralRules[locale]
+	if i := strings.IndexAny(locale, "-_"); !ok && i > 0 {
+		rule, ok = pluralRules[locale[:i]]
+	}
+	if !ok {
+		rule = plural
+	}
+	i := rule(count, len(forms))
+	if i < 0 || i >= len(forms) {
+		must(fmt.Errorf("plural rule of locale %s chose form %d of %d", locale, i, len(forms)))
+	}
+	return strings.TrimSpace(forms[i])
+}
+
+// executeTranslations executes the translations of the text node into data fields,
+// which are interpolated in place of the translations.
+// Params are quoted text, numbers or dotted paths of data fields.
+func (tmpl *template) executeTranslations(node *html.Node, data map[string]interface{}) {
+	node.Data = translateTag.ReplaceAllStringFunc(node.Data, func(tag string) string {
+		match := translateTag.FindStringSubmatch(tag)
+		key, err := strconv.Unquote(match[2])
+		must(err)
+		locale := translationLocale()
+		msg := translate(locale, key)
+		params := translateParam.FindAllStringSubmatch(match[3], -1)
+		// Params are interpolated in a single pass, so values are never interpolated again.
+		var replacements []string
+		if match[1] == "tc" {
+			if len(params) == 0 {
+				must(fmt.Errorf("missing count of plural translation: %s", key))
+			}
+			count := int(toFloat(translationParam(params[0][2], data)))
+			msg = pluralize(locale, msg, count)
+			replacements = append(replacements, "{count}", strconv.Itoa(count), "{n}", strconv.Itoa(count))
+		}
+		for i, param := range params {
+			value := fmt.Sprint(translationParam(param[2], data))
+			replacements = append(replacements, "{"+strconv.Itoa(i)+"}", value)
+			if param[1] != "" {
+				replacements = append(replacements, "{"+param[1]+"}", value)
+			} else if !strings.HasPrefix(param[2], `"`) {
+				replacements = append(replacements, "{"+param[2]+"}", value)
+			}
+		}
+		msg = strings.NewReplacer(replacements...).Replace(msg)
+
+		key = fmt.Sprintf("$t%d", tmpl.id)
+		tmpl.id++
+		data[key] = msg
+		return "{{" + key + "}}"
+	})
+}
+
+// translationParam returns the value of the param of a translation.
+// Unknown fields are nil.
+func translationParam(param string, data map[string]interface{}) interface{} {
+	if strings.HasPrefix(param, `"`) {
+		text, err := strconv.Unquote(param)
+		must(err)
+		return text
+	}
+	if n, err := strconv.ParseFloat(param, 64); err == nil {
+		return n
+	}
+	value, _ := resolvePath(data, param)
+	return unformat(value)
+}
@@ -0,0 +1,89 @@
+// Package icon renders icons inline from embedded svg sprites.
+package icon
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/norunners/vue"
+	"html"
+	"io/ioutil"
+	"net/http"
+	"path"
+)
+
+// defaultSize is the size of icons in pixels without a size prop.
+const defaultSize = "24"
+
+// defaultColor is the color of icons without a color prop, which is the color of the text.
+const defaultColor = "currentColor"
+
+// Sprite is a set of icons by name.
+type Sprite struct {
+	icons map[string]symbol
+}
+
+// symbol is an icon of an svg sprite.
+type symbol struct {
+	ID      string `xml:"id,attr"`
+	ViewBox string `xml:"viewBox,attr"`
+	Inner   string `xml:",innerxml"`
+}
+
+// sprite is an svg sprite of symbols, which may be defined within defs.
+type sprite struct {
+	Symbols []symbol `xml:"symbol"`
+	Defs    []symbol `xml:"defs>symbol"`
+}
+
+// Load loads the svg sprite by name from the file system, e.g. a generated http.FileSystem.
+func Load(fsys http.FileSystem, name string) (*Sprite, error) {
+	file, err := fsys.Open(path.Join("/", name))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	b, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// Parse parses the svg sprite of symbols, which are named by their ids.
+func Parse(svg []byte) (*Sprite, error) {
+	var sp sprite
+	if err := xml.Unmarshal(svg, &sp); err != nil {
+		return nil, err
+	}
+	s := &Sprite{icons: make(map[string]symbol)}
+	for _, sym := range append(sp.Symbols, sp.Defs...) {
+		s.icons[sym.ID] = sym
+	}
+	return s, nil
+}
+
+// Component creates a functional component which renders the icon inline by the name prop,
+// e.g. <app-icon name="home" size="32" color="red">.
+// The size is in pixels, 24 by default, and the color is the color of the text by default.
+func (s *Sprite) Component() *vue.Comp {
+	return vue.Functional(func(props map[string]interface{}) string {
+		name := prop(props, "name", "")
+		sym, ok := s.icons[name]
+		if !ok {
+			panic(fmt.Errorf("unknown icon: %s", name))
+		}
+		size := html.EscapeString(prop(props, "size", defaultSize))
+		color := html.EscapeString(prop(props, "color", defaultColor))
+		return fmt.Sprintf(`<svg class="icon icon-%s" width="%s" height="%s" viewBox="%s" fill="%s" aria-hidden="true">%s</svg>`,
+			html.EscapeString(name), size, size, html.EscapeString(sym.ViewBox), color, sym.Inner)
+	}, "name", "size", "color")
+}
+
+// prop returns the prop as text or the default for unset props.
+func prop(props map[string]interface{}, name, def string) string {
+	value, ok := props[name]
+	if !ok || value == nil {
+		return def
+	}
+	return fmt.Sprint(value)
+}
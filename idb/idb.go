@@ -0,0 +1,208 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Package idb persists data to IndexedDB, e.g. datasets too large for local storage.
+// Data is loaded once mounted, then written behind changes after a debounce delay,
+// so bursts of changes write once.
+package idb
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/norunners/vue"
+	"github.com/norunners/vue/store"
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// objectStore is the name of the object store of persisted values.
+const objectStore = "vue"
+
+// version is the version of the database schema.
+const version = 1
+
+// DB is an IndexedDB database of json values by key.
+type DB struct {
+	db js.Value
+}
+
+// Open opens the database by name, which is created as needed.
+// Open blocks, so it must be called from a goroutine, not from a callback.
+func Open(name string) (*DB, error) {
+	indexedDB := js.Global().Get("indexedDB")
+	if indexedDB == js.Undefined() {
+		return nil, fmt.Errorf("indexeddb is unavailable")
+	}
+	req := indexedDB.Call("open", name, version)
+	onUpgrade(req, objectStore)
+	db, err := wait(req)
+	if err != nil {
+		return nil, err
+	}
+	return &DB{db: db}, nil
+}
+
+// Get gets the value by key. Returns false for unknown keys.
+// Get blocks, so it must be called from a goroutine.
+func (db *DB) Get(key string) ([]byte, bool, error) {
+	value, err := wait(db.store("readonly").Call("get", key))
+	if err != nil {
+		return nil, false, err
+	}
+	if value == js.Undefined() {
+		return nil, false, nil
+	}
+	return []byte(value.String()), true, nil
+}
+
+// Put puts the value by key.
+// Put blocks, so it must be called from a goroutine.
+func (db *DB) Put(key string, value []byte) error {
+	_, err := wait(db.store("readwrite").Call("put", string(value), key))
+	return err
+}
+
+// Delete deletes the value by key.
+// Delete blocks, so it must be called from a goroutine.
+func (db *DB) Delete(key string) error {
+	_, err := wait(db.store("readwrite").Call("delete", key))
+	return err
+}
+
+// store returns the object store of a new transaction by mode.
+func (db *DB) store(mode string) js.Value {
+	return db.db.Call("transaction", objectStore, mode).Call("objectStore", objectStore)
+}
+
+// Data is the option of root components which persists the data by key.
+// The data is loaded once mounted, then written behind renders after the delay.
+// Renders before the data loads are not written.
+// Each mounted root writes independently, so the option may be shared by roots.
+func Data(db *DB, key string, delay time.Duration) vue.Option {
+	var mu sync.Mutex
+	writers := make(map[vue.Context]*writer)
+	mounted := vue.Mounted(func(context vue.Context) {
+		w := &writer{db: db, key: key, delay: delay}
+		mu.Lock()
+		writers[context] = w
+		mu.Unlock()
+		context.Go(func() error {
+			value, ok, err := db.Get(key)
+			if err != nil {
+				return err
+			}
+			context.Update(func(data interface{}) {
+				if ok {
+					if err := json.Unmarshal(value, data); err != nil {
+						warn(fmt.Errorf("persisted data is invalid: %s: %v", key, err))
+					}
+				}
+				w.load()
+			})
+			return nil
+		})
+	})
+	updated := vue.Updated(func(context vue.Context) {
+		mu.Lock()
+		w, ok := writers[context]
+		mu.Unlock()
+		if !ok {
+			return
+		}
+		value, err := json.Marshal(context.Data())
+		if err != nil {
+			warn(err)
+			return
+		}
+		w.write(value)
+	})
+	unmounted := vue.Unmounted(func(context vue.Context) {
+		mu.Lock()
+		delete(writers, context)
+		mu.Unlock()
+	})
+	return func(comp *vue.Comp) {
+		mounted(comp)
+		updated(comp)
+		unmounted(comp)
+	}
+}
+
+// Store is the option of stores which persists the state of the store and its modules by key.
+// The state is loaded when the store is created, then written behind mutations after the delay.
+// Mutations before the state loads are not written.
+func Store(db *DB, key string, delay time.Duration) store.Option {
+	w := &writer{db: db, key: key, delay: delay}
+	return store.Plugin(func(s *store.Store) {
+		go func() {
+			value, ok, err := db.Get(key)
+			if err != nil {
+				warn(err)
+			} else if ok {
+				if err := s.ReplaceState(value); err != nil {
+					warn(fmt.Errorf("persisted state is invalid: %s: %v", key, err))
+				}
+			}
+			w.load()
+		}()
+		s.Subscribe(func(store.Committed, interface{}) {
+			value, err := s.SnapshotState()
+			if err != nil {
+				warn(err)
+				return
+			}
+			w.write(value)
+		})
+	})
+}
+
+// writer writes values behind after a debounce delay.
+type writer struct {
+	db    *DB
+	key   string
+	delay time.Duration
+
+	mu     sync.Mutex
+	loaded bool
+	value  []byte
+	timer  *time.Timer
+}
+
+// load marks the value loaded, so later values are written.
+func (w *writer) load() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.loaded = true
+}
+
+// write writes the value after the delay unless a later value is written meanwhile.
+// Values are skipped until loaded.
+func (w *writer) write(value []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.loaded {
+		return
+	}
+	w.value = value
+	if w.timer == nil {
+		w.timer = time.AfterFunc(w.delay, w.flush)
+		return
+	}
+	w.timer.Reset(w.delay)
+}
+
+// flush writes the latest value.
+func (w *writer) flush() {
+	w.mu.Lock()
+	value := w.value
+	w.mu.Unlock()
+	if err := w.db.Put(w.key, value); err != nil {
+		warn(fmt.Errorf("write %s: %v", w.key, err))
+	}
+}
+
+// warn logs the error as a warning to the console.
+func warn(err error) {
+	js.Global().Get("console").Call("warn", fmt.Sprintf("idb: %v", err))
+}
@@ -0,0 +1,44 @@
+//go:build js && wasm
+// +build js,wasm
+
+package idb
+
+import (
+	"syscall/js"
+)
+
+// wait blocks until the request succeeds or fails then returns its result.
+// Wait must be called from a goroutine, not from a callback.
+func wait(req js.Value) (js.Value, error) {
+	results := make(chan js.Value, 1)
+	errs := make(chan error, 1)
+	success := js.NewCallback(func([]js.Value) {
+		results <- req.Get("result")
+	})
+	defer success.Release()
+	failure := js.NewCallback(func([]js.Value) {
+		errs <- js.Error{Value: req.Get("error")}
+	})
+	defer failure.Release()
+
+	req.Set("onsuccess", success)
+	req.Set("onerror", failure)
+	select {
+	case result := <-results:
+		return result, nil
+	case err := <-errs:
+		return js.Undefined(), err
+	}
+}
+
+// upgradeHandler returns the handler of upgrades which creates the object store by name.
+// Callbacks are asynchronous, but object stores must be created during the upgrade,
+// so the handler is a JavaScript function.
+var upgradeHandler = js.Global().Get("Function").New("req", "name", `return function() {
+	req.result.createObjectStore(name);
+}`)
+
+// onUpgrade sets the handler of upgrades of the open request, which creates the object store by name.
+func onUpgrade(req js.Value, name string) {
+	req.Set("onupgradeneeded", upgradeHandler.Invoke(req, name))
+}
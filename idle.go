@@ -0,0 +1,38 @@
+package vue
+
+import (
+	"time"
+)
+
+// idleField is the data field of the idle state.
+const idleField = "Idle"
+
+// idle is the user activity state of a component.
+type idle struct {
+	timeout  time.Duration
+	onIdle   func(Context)
+	onActive func(Context)
+	idle     bool
+	timer    *time.Timer
+}
+
+// Idle is the idle option for root components.
+// The component becomes idle after no user input for the timeout and active on the next input,
+// e.g. for auto logout or presence.
+// The idle state is mapped to the Idle data field unless data has the same field, e.g. v-if="Idle".
+// The transition functions are called on idle and active transitions, either may be nil.
+func Idle(timeout time.Duration, onIdle, onActive func(Context)) Option {
+	return func(comp *Comp) {
+		comp.idle = &idle{timeout: timeout, onIdle: onIdle, onActive: onActive}
+	}
+}
+
+// idleData maps the idle state to data.
+func (vm *ViewModel) idleData() {
+	if vm.comp.idle == nil {
+		return
+	}
+	if _, ok := vm.data[idleField]; !ok {
+		vm.data[idleField] = vm.comp.idle.idle
+	}
+}
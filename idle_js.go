@@ -0,0 +1,50 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"github.com/gowasm/go-js-dom"
+	"time"
+)
+
+// idleEvents are the types of user input which make the component active.
+var idleEvents = []string{"mousemove", "mousedown", "keydown", "touchstart", "wheel", "scroll"}
+
+// watchIdle watches user input on the document to transition the idle state.
+// The timer and listeners are removed once the root is unmounted.
+func (vm *ViewModel) watchIdle() {
+	idle := vm.comp.idle
+	if idle == nil || vm.comp.isSub {
+		return
+	}
+
+	// The timer runs on a goroutine, so the idle state is updated before the next render.
+	idle.timer = time.AfterFunc(idle.timeout, func() {
+		vm.update(func() {
+			idle.idle = true
+			vm.breadcrumb("idle")
+			if idle.onIdle != nil {
+				idle.onIdle(vm)
+			}
+		})
+	})
+	vm.unlisten = append(vm.unlisten, func() {
+		idle.timer.Stop()
+	})
+	for _, typ := range idleEvents {
+		vm.listen(document, typ, func(dom.Event) {
+			idle.timer.Reset(idle.timeout)
+			if !idle.idle {
+				return
+			}
+			defer vm.report()
+			idle.idle = false
+			vm.breadcrumb("active")
+			if idle.onActive != nil {
+				idle.onActive(vm)
+			}
+			vm.render()
+		})
+	}
+}
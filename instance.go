@@ -0,0 +1,144 @@
+package vue
+
+import (
+	"reflect"
+	"strconv"
+	"sync/atomic"
+)
+
+// Definitions of components are shared by the root view models of a page, e.g. registered components,
+// so each root executes instances of the definitions, isolating their view models, props, regions and lifecycle.
+// Widgets of a server rendered site may each create a root view model by New, and unmount independently.
+// Instances copy the data of the definition and its mixins, so instances of the same definition do not share data,
+// e.g. sibling elements of the same subcomponent or the items of a for attribute.
+
+// scopes is incremented atomically to identify the scope of each instance.
+var scopes int64
+
+// instanceKey identifies the instance of a subcomponent by its parent instance, element and position.
+// The position is the bound key of the element, e.g. of the items of a for attribute,
+// otherwise the count of the preceding elements of the subcomponent within the template of the parent.
+type instanceKey struct {
+	parent   *Comp
+	element  string
+	position string
+}
+
+// instance returns the instance of the subcomponent definition of the element at the position within the parent,
+// which is created for the root view model on first use, or when the definition changes, e.g. an async component resolves.
+func (vm *ViewModel) instance(parent, def *Comp, element, position string) *Comp {
+	key := instanceKey{parent: parent, element: element, position: position}
+	if sub, ok := vm.instances[key]; ok && sub.def == def {
+		return sub
+	}
+
+	sub := def.clone()
+	if vm.instances == nil {
+		vm.instances = make(map[instanceKey]*Comp)
+	}
+	vm.instances[key] = sub
+	return sub
+}
+
+// clone returns a new instance of the component definition.
+func (def *Comp) clone() *Comp {
+	comp := *def
+	comp.def = def
+	comp.props = make(map[string]interface{}, len(def.props))
+	for prop, value := range def.props {
+		comp.props[prop] = value
+	}
+	comp.bound = make(map[string]struct{}, len(def.bound))
+	for prop := range def.bound {
+		comp.bound[prop] = struct{}{}
+	}
+	comp.data = copyData(def.data)
+	comp.mixins = make([]interface{}, len(def.mixins))
+	for i, mixin := range def.mixins {
+		comp.mixins[i] = copyData(mixin)
+	}
+	comp.alive = append([]string(nil), def.alive...)
+	comp.unmounts = nil
+	comp.regions = nil
+	comp.scope = ""
+	comp.vm = nil
+	return &comp
+}
+
+// instanceScope returns the scope of the component instance, which is created on first use,
+// e.g. to scope the refs and methods of the elements rendered by the instance.
+func (comp *Comp) instanceScope() string {
+	if comp.scope == "" {
+		comp.scope = strconv.FormatInt(atomic.AddInt64(&scopes, 1), 10)
+	}
+	return comp.scope
+}
+
+// copyData returns a deep copy of the data, e.g. the pointer to the struct of the data option.
+func copyData(data interface{}) interface{} {
+	if data == nil {
+		return nil
+	}
+	val := reflect.ValueOf(data)
+	dst := reflect.New(val.Type()).Elem()
+	copyValue(dst, val, make(map[uintptr]reflect.Value))
+	return dst.Interface()
+}
+
+// copyValue recursively copies the value into the destination.
+// Pointers which were copied are reused by their copy, so cycles are kept.
+// Unexported fields, functions and channels are copied shallowly.
+func copyValue(dst, src reflect.Value, copied map[uintptr]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		if ptr, ok := copied[src.Pointer()]; ok && ptr.Type() == src.Type() {
+			dst.Set(ptr)
+			return
+		}
+		ptr := reflect.New(src.Type().Elem())
+		copied[src.Pointer()] = ptr
+		dst.Set(ptr)
+		copyValue(ptr.Elem(), src.Elem(), copied)
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if field := dst.Field(i); field.CanSet() {
+				copyValue(field, src.Field(i), copied)
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i), copied)
+		}
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i), copied)
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMap(src.Type()))
+		for _, key := range src.MapKeys() {
+			value := reflect.New(src.Type().Elem()).Elem()
+			copyValue(value, src.MapIndex(key), copied)
+			dst.SetMapIndex(key, value)
+		}
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		value := reflect.New(src.Elem().Type()).Elem()
+		copyValue(value, src.Elem(), copied)
+		dst.Set(value)
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,90 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"golang.org/x/net/html"
+	"reflect"
+	"testing"
+)
+
+type counterData struct {
+	Count int
+}
+
+// Increment increments the count of the instance.
+func (data *counterData) Increment(context Context) {
+	data.Count++
+}
+
+type counterMixin struct {
+	Step int
+}
+
+type countersData struct {
+	Items []string
+}
+
+func newCounters() *Comp {
+	counter := Component(
+		Template(`<button v-on:click="Increment">{{ Count }}</button>`),
+		Data(&counterData{}),
+		Mixin(Data(&counterMixin{Step: 1})),
+		Methods(&counterData{}),
+	)
+	return Component(
+		Template(`<div><counter></counter><counter></counter><counter v-for="item in Items" v-bind:key="item"></counter></div>`),
+		Data(&countersData{Items: []string{"a", "b"}}),
+		Sub("counter", counter),
+	)
+}
+
+// buttons returns the buttons of the rendered tree in order.
+func buttons(node *html.Node) []*html.Node {
+	var nodes []*html.Node
+	if node.Type == html.ElementNode && node.Data == "button" {
+		nodes = append(nodes, node)
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		nodes = append(nodes, buttons(child)...)
+	}
+	return nodes
+}
+
+func TestInstances(t *testing.T) {
+	vm := Headless(newCounters(), nil)
+	click := func(i int) {
+		vm.Dispatch(buttons(vm.Node())[i], NewEvent("click", EventInit{}))
+	}
+	click(1)
+	click(2)
+	click(2)
+	check := func(want ...string) {
+		t.Helper()
+		var got []string
+		for _, button := range buttons(vm.Node()) {
+			got = append(got, button.FirstChild.Data)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got counts %v, want %v", got, want)
+		}
+	}
+	check("0", "1", "2", "0")
+
+	// Instances of keyed items follow their items.
+	vm.Set("Items", []string{"b", "a"})
+	vm.ForceUpdate()
+	check("0", "1", "0", "2")
+}
+
+func TestCloneMixins(t *testing.T) {
+	def := newCounters().subs["counter"]
+	comp := def.clone()
+	if comp.mixins[0] == def.mixins[0] {
+		t.Error("mixin data is shared with the definition")
+	}
+	if step := comp.mixins[0].(*counterMixin).Step; step != 1 {
+		t.Errorf("got step %d, want 1", step)
+	}
+}
@@ -0,0 +1,82 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"syscall/js"
+	"time"
+)
+
+// await blocks until the promise settles then returns the value or the error.
+// Await must be called from a goroutine, not from a callback.
+func await(promise js.Value) (js.Value, error) {
+	values := make(chan js.Value, 1)
+	errs := make(chan error, 1)
+	resolve := js.NewCallback(func(args []js.Value) {
+		values <- args[0]
+	})
+	defer resolve.Release()
+	reject := js.NewCallback(func(args []js.Value) {
+		errs <- js.Error{Value: args[0]}
+	})
+	defer reject.Release()
+
+	promise.Call("then", resolve, reject)
+	select {
+	case value := <-values:
+		return value, nil
+	case err := <-errs:
+		return js.Undefined(), err
+	}
+}
+
+// setItem sets the item of the web storage by key.
+// Errors of the storage are returned instead of panicking, e.g. QuotaExceededError of full storage.
+func setItem(storage js.Value, key, value string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			jsErr, ok := r.(js.Error)
+			if !ok {
+				panic(r)
+			}
+			err = jsErr
+		}
+	}()
+	storage.Call("setItem", key, value)
+	return nil
+}
+
+// listen adds the function as an event listener of the target.
+// The function receives the event. The returned function removes the listener.
+func listen(target js.Value, typ string, fn func(event js.Value)) func() {
+	cb := js.NewCallback(func(args []js.Value) {
+		fn(args[0])
+	})
+	target.Call("addEventListener", typ, cb)
+	return func() {
+		target.Call("removeEventListener", typ, cb)
+		cb.Release()
+	}
+}
+
+// setTimeout calls the function after the duration by a timer of the browser,
+// so the function runs on the render loop like other callbacks, unlike time.AfterFunc.
+func setTimeout(d time.Duration, fn func()) {
+	var cb js.Callback
+	cb = js.NewCallback(func([]js.Value) {
+		cb.Release()
+		fn()
+	})
+	js.Global().Call("setTimeout", cb, float64(d)/float64(time.Millisecond))
+}
+
+// requestAnimationFrame calls the function before the next repaint.
+func requestAnimationFrame(fn func()) {
+	var cb js.Callback
+	cb = js.NewCallback(func([]js.Value) {
+		cb.Release()
+		fn()
+	})
+	js.Global().Call("requestAnimationFrame", cb)
+}
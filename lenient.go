@@ -0,0 +1,31 @@
+package vue
+
+import (
+	"fmt"
+)
+
+// Lenient is the lenient mode option for components.
+// Directives which refer to unknown data fields are skipped and logged as warnings instead of panicking,
+// e.g. while partially populated data loads asynchronously.
+// Missing bindings and html render empty, missing loops render no elements and missing models bind empty.
+// Components are strict by default.
+func Lenient() Option {
+	return func(comp *Comp) {
+		comp.lenient = true
+	}
+}
+
+// lookup returns the value of the data field.
+// Unknown fields panic in strict mode, otherwise they are logged as warnings.
+func (tmpl *template) lookup(data map[string]interface{}, field string) (interface{}, bool) {
+	value, ok := resolvePath(data, field)
+	if ok {
+		return value, true
+	}
+	err := fmt.Errorf("unknown data field: %s", field)
+	if !tmpl.comp.lenient {
+		must(err)
+	}
+	tmpl.comp.warn(err)
+	return nil, false
+}
@@ -0,0 +1,129 @@
+package vue
+
+// Subcomponents are mounted when rendered after not being rendered, then unmounted once a render of their root
+// no longer renders them, e.g. by v-if. Subcomponents of reused and throttled executions stay mounted.
+
+// Mounted is the mounted hook option for components.
+// The function is called after the render which mounts the component updates the dom,
+// e.g. to listen on behalf of the component.
+func Mounted(fn func(Context)) Option {
+	return func(comp *Comp) {
+		comp.mountedHooks = append(comp.mountedHooks, fn)
+	}
+}
+
+// Unmounted is the unmounted hook option for components.
+// The function is called after the render which unmounts the subcomponent updates the dom,
+// or once the root component is unmounted, e.g. to stop timers of the component.
+func Unmounted(fn func(Context)) Option {
+	return func(comp *Comp) {
+		comp.unmountedHooks = append(comp.unmountedHooks, fn)
+	}
+}
+
+// OnUnmount calls the function once the component is unmounted,
+// e.g. to remove listeners registered on behalf of the component.
+func (vm *ViewModel) OnUnmount(fn func()) {
+	vm.comp.unmounts = append(vm.comp.unmounts, fn)
+}
+
+// mount marks the subcomponent of the element rendered by the current render.
+// Subcomponents which were not mounted emit the mount event, then their mounted hooks are queued.
+func (vm *ViewModel) mount(sub *Comp, element string) {
+	sub.rendered = sub.renders()
+	if vm.mounts == nil {
+		vm.mounts = make(map[*Comp]struct{})
+	}
+	vm.mounts[sub] = struct{}{}
+	if sub.mounted {
+		return
+	}
+	sub.mounted = true
+	vm.emit(AnalyticsMount, element)
+	for _, hook := range sub.mountedHooks {
+		hook := hook
+		vm.lifecycle = append(vm.lifecycle, func() {
+			hook(sub.context())
+		})
+	}
+}
+
+// unmountSubs unmounts the subcomponents which are no longer rendered, then queues their unmounted hooks.
+func (vm *ViewModel) unmountSubs() {
+	for sub := range vm.mounts {
+		if !sub.rendering() {
+			vm.unmount(sub)
+		}
+	}
+}
+
+// unmount unmounts the component, then queues the functions and hooks of unmounting.
+func (vm *ViewModel) unmount(comp *Comp) {
+	delete(vm.mounts, comp)
+	comp.mounted = false
+	unmounts := comp.unmounts
+	comp.unmounts = nil
+	vm.lifecycle = append(vm.lifecycle, func() {
+		for _, unmount := range unmounts {
+			unmount()
+		}
+	})
+	for _, hook := range comp.unmountedHooks {
+		hook := hook
+		context := comp.context()
+		vm.lifecycle = append(vm.lifecycle, func() {
+			hook(context)
+		})
+	}
+}
+
+// rendering determines if the subcomponent was rendered by the current render,
+// or if its execution was reused by an ancestor.
+func (sub *Comp) rendering() bool {
+	renders := sub.renders()
+	if sub.rendered == renders {
+		return true
+	}
+	for comp := sub.parent; comp != nil; comp = comp.parent {
+		if comp.reused == renders {
+			return true
+		}
+	}
+	return false
+}
+
+// rootMounted queues the mounted hooks of the root component.
+func (vm *ViewModel) rootMounted() {
+	for _, hook := range vm.comp.mountedHooks {
+		hook := hook
+		vm.lifecycle = append(vm.lifecycle, func() {
+			hook(vm)
+		})
+	}
+}
+
+// Unmount unmounts the root component independently of other roots of the page,
+// e.g. a widget removed from a server rendered site.
+// The rendered elements and event listeners are removed, then the subcomponents and the root are unmounted.
+// Renders after unmounting are ignored.
+func (vm *ViewModel) Unmount() {
+	if vm.comp.isSub || vm.unmounted {
+		return
+	}
+	vm.mu.Lock()
+	vm.unmounted = true
+	vm.mu.Unlock()
+	vm.clear()
+
+	for sub := range vm.mounts {
+		vm.unmount(sub)
+	}
+	vm.unmount(vm.comp)
+	lifecycle := vm.lifecycle
+	vm.lifecycle = nil
+	for _, hook := range lifecycle {
+		hook()
+	}
+
+	removeRoot(vm)
+}
@@ -0,0 +1,26 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"golang.org/x/net/html"
+)
+
+// clear removes the rendered elements and event listeners of the unmounted root.
+func (vm *ViewModel) clear() {
+	for _, unlisten := range vm.unlisten {
+		unlisten()
+	}
+	vm.unlisten = nil
+
+	empty := &html.Node{Type: html.ElementNode}
+	if vm.vnode != nil {
+		vm.vnode.render(empty)
+	}
+	for _, target := range vm.teleports {
+		target.render(empty)
+	}
+	vm.restoreTitle()
+	vm.restoreHead()
+}
@@ -0,0 +1,110 @@
+// Package loader renders the html which loads wasm applications with graceful degradation.
+// The package has no js dependencies, so it is imported by servers and build tools.
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// fallbackID is the id of the element which contains the fallback.
+const fallbackID = "vue-fallback"
+
+// Option is an option of the loader.
+type Option func(*loader)
+
+// loader loads a wasm application.
+type loader struct {
+	wasm     string
+	exec     string
+	report   string
+	fallback string
+}
+
+// Wasm is the url of the wasm binary, main.wasm by default.
+func Wasm(url string) Option {
+	return func(l *loader) {
+		l.wasm = url
+	}
+}
+
+// Exec is the url of the wasm_exec.js support script of the Go distribution, wasm_exec.js by default.
+func Exec(url string) Option {
+	return func(l *loader) {
+		l.exec = url
+	}
+}
+
+// Report is the url which receives failures to instantiate the application by beacon,
+// as a json object with the error and the user agent.
+func Report(url string) Option {
+	return func(l *loader) {
+		l.report = url
+	}
+}
+
+// Fallback is the html shown without js or if the application fails to load,
+// e.g. a message or the server side rendered output of the application.
+func Fallback(html string) Option {
+	return func(l *loader) {
+		l.fallback = html
+	}
+}
+
+// HTML returns the html which loads the application,
+// including the fallback shown on unsupported browsers or instantiation failures.
+func HTML(options ...Option) string {
+	l := &loader{
+		wasm:     "main.wasm",
+		exec:     "wasm_exec.js",
+		fallback: "<p>This application requires a browser with WebAssembly support.</p>",
+	}
+	for _, option := range options {
+		option(l)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<noscript>%s</noscript>`, l.fallback)
+	fmt.Fprintf(&b, `<div id="%s" hidden>%s</div>`, fallbackID, l.fallback)
+	fmt.Fprintf(&b, `<script src="%s"></script>`, l.exec)
+	fmt.Fprintf(&b, `<script>%s</script>`, l.script())
+	return b.String()
+}
+
+// script returns the script which instantiates the application or shows the fallback.
+func (l *loader) script() string {
+	return fmt.Sprintf(`(function() {
+  var report = %s;
+  var fail = function(err) {
+    document.getElementById(%s).hidden = false;
+    if (report && navigator.sendBeacon) {
+      navigator.sendBeacon(report, JSON.stringify({error: String(err), userAgent: navigator.userAgent}));
+    }
+  };
+  if (typeof WebAssembly !== "object" || typeof Go !== "function") {
+    fail(new Error("WebAssembly is not supported"));
+    return;
+  }
+  var go = new Go();
+  fetch(%s).then(function(res) {
+    if (!res.ok) {
+      throw new Error("failed to fetch wasm: " + res.status);
+    }
+    return res.arrayBuffer();
+  }).then(function(bytes) {
+    return WebAssembly.instantiate(bytes, go.importObject);
+  }).then(function(result) {
+    return go.run(result.instance);
+  }).catch(fail);
+})();`, quote(l.report), quote(fallbackID), quote(l.wasm))
+}
+
+// quote quotes the string as a js string literal which is safe within script elements.
+func quote(s string) string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,66 @@
+package vue
+
+import (
+	"strings"
+	"sync"
+)
+
+// Catalogs of messages are loaded on demand by locale, which are fetched as json once first translated.
+
+// catalogs is the state of loading catalogs by locale.
+var catalogs struct {
+	sync.Mutex
+	url      string
+	fallback string
+	loading  map[string]bool
+}
+
+// LazyMessages loads the messages of locales on demand from the url of the locale,
+// e.g. LazyMessages("/locales/{locale}.json", "en"), which is a json object of messages by key.
+// The catalog of a locale is fetched once its messages are first translated, e.g. by SetLocale,
+// then all root view models render again once the catalog arrives.
+// Until then, messages of the fallback locale are rendered, which are registered by Messages.
+// Locales with registered messages are not fetched. Catalogs which fail to load are warned,
+// then messages of the fallback locale are rendered.
+func LazyMessages(url, fallback string) {
+	catalogs.Lock()
+	defer catalogs.Unlock()
+	catalogs.url = url
+	catalogs.fallback = fallback
+	catalogs.loading = make(map[string]bool)
+}
+
+// catalogLocale returns the locale of the messages to translate,
+// which is the fallback locale while the catalog of the locale loads.
+// Loading starts once the catalog of the locale is first translated.
+func catalogLocale(locale string) string {
+	catalogs.Lock()
+	defer catalogs.Unlock()
+	if catalogs.url == "" || locale == catalogs.fallback {
+		return locale
+	}
+	loading, ok := catalogs.loading[locale]
+	if !ok {
+		if _, registered := messages[locale]; registered {
+			return locale
+		}
+		loading = true
+		catalogs.loading[locale] = true
+		go loadCatalog(locale, strings.Replace(catalogs.url, "{locale}", locale, -1))
+	}
+	if loading {
+		return catalogs.fallback
+	}
+	return locale
+}
+
+// catalogLoaded registers the loaded messages of the locale before the next render of all root view models.
+// Catalogs which failed to load are never loaded, so the fallback locale is kept.
+func catalogLoaded(locale string, msgs map[string]string) {
+	(&Installer{}).Update(func() {
+		Messages(locale, msgs)
+		catalogs.Lock()
+		catalogs.loading[locale] = false
+		catalogs.Unlock()
+	})
+}
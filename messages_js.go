@@ -0,0 +1,41 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"encoding/json"
+	"fmt"
+	"syscall/js"
+)
+
+// loadCatalog fetches the catalog of the locale from the url, then registers its messages.
+// Errors are warned to the console. LoadCatalog must be called from a goroutine, not from a callback.
+func loadCatalog(locale, url string) {
+	msgs, err := fetchCatalog(url)
+	if err != nil {
+		js.Global().Get("console").Call("warn", fmt.Sprintf("catalog of locale %s is not loaded: %v", locale, err))
+		return
+	}
+	catalogLoaded(locale, msgs)
+}
+
+// fetchCatalog fetches the json object of messages by key from the url.
+func fetchCatalog(url string) (map[string]string, error) {
+	response, err := await(js.Global().Call("fetch", url))
+	if err != nil {
+		return nil, err
+	}
+	if !response.Get("ok").Bool() {
+		return nil, fmt.Errorf("status %d", response.Get("status").Int())
+	}
+	text, err := await(response.Call("text"))
+	if err != nil {
+		return nil, err
+	}
+	var msgs map[string]string
+	if err := json.Unmarshal([]byte(text.String()), &msgs); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
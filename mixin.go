@@ -0,0 +1,89 @@
+package vue
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Mixin is the mixin option for components.
+// The options of the mixin are merged into the component, e.g. to reuse behaviors across components.
+// Data fields, methods, computed, subcomponents and props of the mixin are included by name.
+// The component takes precedence over mixins regardless of the order of options,
+// then earlier mixins take precedence over later mixins.
+// Mounted, unmounted and updated hooks of mixins are merged, and are called before the hooks of the component.
+// Global options are not applied to mixins, since they are applied to the component.
+// Mixin data must be a pointer to be mutable by methods.
+func Mixin(options ...Option) Option {
+	return func(comp *Comp) {
+		mixin := newComp()
+		for _, option := range options {
+			option(mixin)
+		}
+		comp.mixins = append(comp.mixins, mixin.data)
+		comp.mixins = append(comp.mixins, mixin.mixins...)
+		for name, function := range mixin.methods {
+			if _, ok := comp.methods[name]; !ok {
+				comp.methods[name] = function
+			}
+		}
+		for name, function := range mixin.computed {
+			if _, ok := comp.computed[name]; !ok {
+				comp.computed[name] = function
+			}
+		}
+		for element, sub := range mixin.subs {
+			if _, ok := comp.subs[element]; !ok {
+				comp.subs[element] = sub
+			}
+		}
+		comp.mountedHooks = mergeHooks(mixin.mountedHooks, comp.mountedHooks)
+		comp.unmountedHooks = mergeHooks(mixin.unmountedHooks, comp.unmountedHooks)
+		comp.updated = mergeHooks(mixin.updated, comp.updated)
+		for prop, value := range mixin.props {
+			if _, ok := comp.props[prop]; !ok {
+				comp.props[prop] = value
+				if propType, ok := mixin.propTypes[prop]; ok {
+					comp.propTypes[prop] = propType
+				}
+			}
+		}
+	}
+}
+
+// mergeHooks returns the hooks of the mixin followed by the hooks of the component.
+func mergeHooks(mixin, comp []func(Context)) []func(Context) {
+	hooks := make([]func(Context), 0, len(mixin)+len(comp))
+	return append(append(hooks, mixin...), comp...)
+}
+
+// mixinData maps the data of mixins to data.
+func (vm *ViewModel) mixinData() {
+	for _, mixin := range vm.comp.mixins {
+		for field, value := range mapStruct(mixin) {
+			if _, ok := vm.data[field]; !ok {
+				vm.data[field] = value
+			}
+		}
+	}
+}
+
+// dataField returns the data field of the component or its mixins by the dotted path.
+func (comp *Comp) dataField(field string) (reflect.Value, bool) {
+	names := strings.Split(field, ".")
+	datas := append([]interface{}{comp.data}, comp.mixins...)
+	for _, data := range datas {
+		value := reflect.Indirect(reflect.ValueOf(data))
+		if value.Kind() != reflect.Struct {
+			continue
+		}
+		if field, ok := structField(value.Type(), names[0]); ok {
+			val, ok := fieldByIndex(value, field.Index)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			val, ok = walkPath(val, names[1:])
+			return reflect.Indirect(val), ok
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,31 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+)
+
+// Attributes of elements to the types of events with modifiers, e.g. data-v-prevent="submit".
+// Methods are called after events are dispatched, so modifiers are handled by listeners of the element instead.
+const (
+	preventAttr = "data-v-prevent"
+	stopAttr    = "data-v-stop"
+)
+
+// modifierAttrs are the attributes of event modifiers by name.
+// The prevent modifier prevents the default action of the event, e.g. submitting a form.
+// The stop modifier stops the propagation of the event beyond the element, e.g. to listeners of the document.
+var modifierAttrs = map[string]string{
+	"prevent": preventAttr,
+	"stop":    stopAttr,
+}
+
+// addModifier adds the type of the event to the modifier attribute of the node.
+func addModifier(node *html.Node, key, typ string) {
+	for i, attr := range node.Attr {
+		if attr.Key == key {
+			node.Attr[i].Val += " " + typ
+			return
+		}
+	}
+	node.Attr = append(node.Attr, html.Attribute{Key: key, Val: typ})
+}
@@ -0,0 +1,84 @@
+package vue
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Push appends the values to the slice of the data field then renders.
+func (vm *ViewModel) Push(field string, values ...interface{}) {
+	slice := vm.kindField(field, reflect.Slice)
+	for _, value := range values {
+		slice.Set(reflect.Append(slice, elemValue(slice.Type().Elem(), value)))
+	}
+	vm.mutated(field)
+}
+
+// Splice removes the count of elements from the slice of the data field at the start index,
+// then inserts the values at the start index and renders.
+func (vm *ViewModel) Splice(field string, start, count int, values ...interface{}) {
+	slice := vm.kindField(field, reflect.Slice)
+	n := slice.Len()
+	if start < 0 || count < 0 || start+count > n {
+		must(fmt.Errorf("splice out of range: [%d:%d] with length %d", start, start+count, n))
+	}
+
+	typ := slice.Type()
+	spliced := reflect.MakeSlice(typ, 0, n-count+len(values))
+	spliced = reflect.AppendSlice(spliced, slice.Slice(0, start))
+	for _, value := range values {
+		spliced = reflect.Append(spliced, elemValue(typ.Elem(), value))
+	}
+	spliced = reflect.AppendSlice(spliced, slice.Slice(start+count, n))
+	slice.Set(spliced)
+	vm.mutated(field)
+}
+
+// SetKey assigns the key of the map of the data field to the value then renders.
+// A nil map is made before assignment.
+func (vm *ViewModel) SetKey(field string, key, value interface{}) {
+	m := vm.kindField(field, reflect.Map)
+	typ := m.Type()
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(typ))
+	}
+	m.SetMapIndex(elemValue(typ.Key(), key), elemValue(typ.Elem(), value))
+	vm.mutated(field)
+}
+
+// DeleteKey deletes the key from the map of the data field then renders.
+func (vm *ViewModel) DeleteKey(field string, key interface{}) {
+	m := vm.kindField(field, reflect.Map)
+	m.SetMapIndex(elemValue(m.Type().Key(), key), reflect.Value{})
+	vm.mutated(field)
+}
+
+// kindField returns the data field which must be of the kind.
+func (vm *ViewModel) kindField(field string, kind reflect.Kind) reflect.Value {
+	val, ok := vm.comp.dataField(field)
+	if !ok {
+		must(fmt.Errorf("unknown data field: %s", field))
+	}
+	if val.Kind() != kind {
+		must(fmt.Errorf("data field is not of kind %s: %s", kind, val.Type()))
+	}
+	return val
+}
+
+// mutated marks the data field as changed then renders.
+func (vm *ViewModel) mutated(field string) {
+	vm.breadcrumb("mutate: %s", field)
+	vm.render()
+}
+
+// elemValue returns the value which must be assignable to the type.
+func elemValue(typ reflect.Type, value interface{}) reflect.Value {
+	if value == nil {
+		return reflect.Zero(typ)
+	}
+	val := reflect.ValueOf(value)
+	if !val.Type().AssignableTo(typ) {
+		must(fmt.Errorf("value is not of type %s: %s", typ, val.Type()))
+	}
+	return val
+}
@@ -1,6 +1,7 @@
 package vue
 
 import (
+	"fmt"
 	"reflect"
 	"runtime"
 	"strings"
@@ -13,13 +14,14 @@ type Option func(*Comp)
 // The root element of a component is query selected from the value, e.g. #app or body.
 func El(el string) Option {
 	return func(comp *Comp) {
-		comp.el = document.QuerySelector(el)
+		comp.el = querySelector(el)
 	}
 }
 
 // Template is the template option for components.
 // The template uses the mustache syntax for rendering.
-// The template must have a single root element.
+// The template may have multiple root elements, a fragment, which render in place without a wrapper element,
+// e.g. the cells of a table row or the items of a grid.
 func Template(tmpl string) Option {
 	return func(comp *Comp) {
 		comp.tmpl = tmpl
@@ -29,6 +31,7 @@ func Template(tmpl string) Option {
 // Data is the data option for components.
 // The scope of the data is within the component.
 // Data must be a pointer to be mutable by methods.
+// Fields are named in templates by the vue tag, e.g. `vue:"todoText"`, otherwise by the field name.
 func Data(data interface{}) Option {
 	return func(comp *Comp) {
 		comp.data = data
@@ -36,13 +39,64 @@ func Data(data interface{}) Option {
 }
 
 // Methods is the methods option for components.
-// The given functions are registered as methods for the component.
-func Methods(functions ...func(context Context)) Option {
+// Methods are given as functions, which are registered by function name,
+// as maps of functions by explicit names, e.g. closures which capture services per instance,
+// or as values of which the exported methods of the signature, e.g. func (data *Data) Add(context vue.Context),
+// are registered by method name. Methods of values of the type of the data are called on the data of the instance,
+// since instances copy the data, otherwise on the value itself:
+//
+//	vue.Methods(Add, map[string]func(vue.Context){"save": store.Save}, &Data{})
+//
+// Panics for other types and for values without methods of the signature.
+func Methods(methods ...interface{}) Option {
 	return func(comp *Comp) {
-		for _, function := range functions {
-			name := funcName(function)
-			comp.methods[name] = function
+		for _, method := range methods {
+			switch method := method.(type) {
+			case func(Context):
+				comp.methods[funcName(method)] = method
+			case map[string]func(Context):
+				for name, function := range method {
+					comp.methods[name] = function
+				}
+			default:
+				comp.valueMethods(method)
+			}
+		}
+	}
+}
+
+// valueMethods registers the exported methods of the value with the signature of methods by method name.
+func (comp *Comp) valueMethods(value interface{}) {
+	val := reflect.ValueOf(value)
+	if !val.IsValid() {
+		must(fmt.Errorf("method is nil"))
+	}
+	typ := val.Type()
+	found := false
+	for i := 0; i < typ.NumMethod(); i++ {
+		if val.Method(i).Type() != contextMethod {
+			continue
 		}
+		comp.methods[typ.Method(i).Name] = valueMethod(val, i)
+		found = true
+	}
+	if !found {
+		must(fmt.Errorf("method is neither a function, a map of functions nor a value with methods of type func(vue.Context): %T", value))
+	}
+}
+
+// contextMethod is the type of methods bound to values.
+var contextMethod = reflect.TypeOf(func(Context) {})
+
+// valueMethod returns the method of the value by index,
+// which is called on the data of the context when of the type of the value.
+func valueMethod(val reflect.Value, i int) func(Context) {
+	return func(context Context) {
+		recv := val
+		if data := reflect.ValueOf(context.Data()); data.IsValid() && data.Type() == val.Type() {
+			recv = data
+		}
+		recv.Method(i).Interface().(func(Context))(context)
 	}
 }
 
@@ -57,6 +111,16 @@ func Computed(functions ...func(Context) interface{}) Option {
 	}
 }
 
+// NamedComputed is the computed option for components by explicit names,
+// e.g. closures or functions named apart from the template.
+func NamedComputed(functions map[string]func(Context) interface{}) Option {
+	return func(comp *Comp) {
+		for name, function := range functions {
+			comp.computed[name] = function
+		}
+	}
+}
+
 // Sub is the subcomponent option for components.
 func Sub(element string, sub *Comp) Option {
 	return func(comp *Comp) {
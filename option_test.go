@@ -0,0 +1,54 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"testing"
+)
+
+type counter struct {
+	Count int
+}
+
+func (c *counter) Increment(Context) {
+	c.Count++
+}
+
+func Reset(context Context) {
+	context.Data().(*counter).Count = 0
+}
+
+func TestMethods(t *testing.T) {
+	step := 10
+	comp := Component(
+		Template(`<p>{{ Count }}</p>`),
+		Data(&counter{}),
+		Methods(Reset, map[string]func(Context){
+			"Step": func(context Context) {
+				context.Data().(*counter).Count += step
+			},
+		}, &counter{}),
+	)
+	data := &counter{}
+	vm := Headless(comp, data)
+	for _, method := range []string{"Increment", "Step", "Increment"} {
+		vm.Call(method)
+	}
+	if data.Count != 12 {
+		t.Errorf("got count %d, want 12", data.Count)
+	}
+	vm.Call("Reset")
+	if got, want := vm.HTML(), "<p>0</p>"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestMethodsInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("value without methods did not panic")
+		}
+	}()
+	Component(Methods(42))
+}
@@ -0,0 +1,117 @@
+package vue
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Fields are referred to by dotted paths in directives, interpolations and the context,
+// e.g. User.Settings.DarkMode, which resolve through nested structs, pointers and maps.
+// Fields of structs are named by the vue tag and keys of maps are strings.
+
+// resolvePath resolves the dotted path of the data.
+// Nil pointers and missing keys of maps resolve to nil.
+// Returns false for unknown fields.
+func resolvePath(data map[string]interface{}, path string) (interface{}, bool) {
+	names := strings.Split(path, ".")
+	value, ok := data[names[0]]
+	if !ok {
+		return nil, false
+	}
+
+	val := reflect.ValueOf(value)
+	for _, name := range names[1:] {
+		for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return nil, true
+			}
+			val = val.Elem()
+		}
+		switch val.Kind() {
+		case reflect.Struct:
+			field, ok := structField(val.Type(), name)
+			if !ok {
+				return nil, false
+			}
+			if val, ok = fieldByIndex(val, field.Index); !ok {
+				return nil, true
+			}
+		case reflect.Map:
+			val = val.MapIndex(reflect.ValueOf(name).Convert(val.Type().Key()))
+			if !val.IsValid() {
+				return nil, true
+			}
+		case reflect.Invalid:
+			return nil, true
+		default:
+			return nil, false
+		}
+	}
+	if !val.IsValid() {
+		return nil, true
+	}
+	return val.Interface(), true
+}
+
+// walkPath walks the names from the settable value through nested structs and pointers.
+// Nil pointers are allocated to be settable.
+func walkPath(val reflect.Value, names []string) (reflect.Value, bool) {
+	for _, name := range names {
+		for val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				val.Set(reflect.New(val.Type().Elem()))
+			}
+			val = val.Elem()
+		}
+		if val.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		field, ok := structField(val.Type(), name)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		if val, ok = fieldByIndex(val, field.Index); !ok {
+			return reflect.Value{}, false
+		}
+	}
+	return val, true
+}
+
+// setPath sets the data field of the dotted path to the value.
+// The last name of the path may be the key of a map.
+func (comp *Comp) setPath(path string, value interface{}) {
+	names := strings.Split(path, ".")
+	parent, ok := comp.dataField(strings.Join(names[:len(names)-1], "."))
+	if ok && len(names) > 1 {
+		if m := reflect.Indirect(parent); m.Kind() == reflect.Map {
+			if m.IsNil() {
+				m.Set(reflect.MakeMap(m.Type()))
+			}
+			key := reflect.ValueOf(names[len(names)-1]).Convert(m.Type().Key())
+			m.SetMapIndex(key, elemValue(m.Type().Elem(), value))
+			return
+		}
+	}
+
+	val, ok := comp.dataField(path)
+	if !ok {
+		must(fmt.Errorf("unknown data field: %s", path))
+	}
+	val.Set(reflect.Indirect(reflect.ValueOf(value)))
+}
+
+// fieldByIndex returns the nested field of the struct value by index.
+// Returns false for nil pointers to embedded structs.
+func fieldByIndex(val reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				return reflect.Value{}, false
+			}
+			val = val.Elem()
+		}
+		val = val.Field(x)
+	}
+	return val, true
+}
@@ -0,0 +1,54 @@
+package vue
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// persistence is the persistence of the data of root components to web storage.
+type persistence struct {
+	key     string
+	fields  []string
+	session bool
+	version int
+	migrate func(from int, state map[string]json.RawMessage) map[string]json.RawMessage
+	saved   string
+}
+
+// persisted is the representation of the state in web storage.
+type persisted struct {
+	Version int                        `json:"version"`
+	State   map[string]json.RawMessage `json:"state"`
+}
+
+// Persist is the persist option for root components.
+// The data fields are persisted to local storage by the key as json before each render, once updates are applied,
+// then restored when the component is created, e.g. preferences or drafts.
+// Errors of the storage, e.g. of full storage, are warned and the fields are saved again by the next render.
+// Fields are named like in templates by dotted paths. All exported fields are persisted without fields.
+func Persist(key string, fields ...string) Option {
+	return func(comp *Comp) {
+		comp.persistence = &persistence{key: key, fields: fields}
+	}
+}
+
+// PersistSession is the persist option for root components by session storage,
+// which persists the data fields until the tab is closed.
+func PersistSession(key string, fields ...string) Option {
+	return func(comp *Comp) {
+		comp.persistence = &persistence{key: key, fields: fields, session: true}
+	}
+}
+
+// PersistVersion is the option of the version of persisted data, which must follow the persist option.
+// State persisted by older versions is migrated by the function before it is restored,
+// e.g. to rename fields. The state is by field name, which may be nil to discard the state.
+func PersistVersion(version int, migrate func(from int, state map[string]json.RawMessage) map[string]json.RawMessage) Option {
+	return func(comp *Comp) {
+		if comp.persistence == nil {
+			must(fmt.Errorf("persist version requires the persist option: %d", version))
+		}
+		comp.persistence.version = version
+		comp.persistence.migrate = migrate
+	}
+}
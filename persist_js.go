@@ -0,0 +1,100 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"encoding/json"
+	"fmt"
+	"syscall/js"
+)
+
+// restorePersisted restores the persisted data fields.
+// Unknown fields are skipped, e.g. of removed fields.
+func (vm *ViewModel) restorePersisted() {
+	p := vm.comp.persistence
+	storage, ok := p.storage()
+	if !ok {
+		return
+	}
+	item := storage.Call("getItem", p.key)
+	if item == js.Null() {
+		return
+	}
+
+	var state persisted
+	if err := json.Unmarshal([]byte(item.String()), &state); err != nil {
+		vm.comp.warn(fmt.Errorf("persisted state is invalid: %s: %v", p.key, err))
+		return
+	}
+	if state.Version != p.version && p.migrate != nil {
+		state.State = p.migrate(state.Version, state.State)
+	}
+	for field, value := range state.State {
+		val, ok := vm.comp.dataField(field)
+		if !ok || !val.CanAddr() {
+			continue
+		}
+		if err := json.Unmarshal(value, val.Addr().Interface()); err != nil {
+			vm.comp.warn(fmt.Errorf("persisted field is invalid: %s: %v", field, err))
+		}
+	}
+}
+
+// savePersisted saves the persisted data fields before the render unless unchanged since the last save.
+func (vm *ViewModel) savePersisted() {
+	p := vm.comp.persistence
+	if p == nil {
+		return
+	}
+	storage, ok := p.storage()
+	if !ok {
+		return
+	}
+
+	fields := p.fields
+	if len(fields) == 0 {
+		for field := range mapStruct(vm.comp.data) {
+			fields = append(fields, field)
+		}
+	}
+	state := persisted{Version: p.version, State: make(map[string]json.RawMessage, len(fields))}
+	for _, field := range fields {
+		val, ok := vm.comp.dataField(field)
+		if !ok {
+			must(fmt.Errorf("unknown data field: %s", field))
+		}
+		b, err := json.Marshal(val.Interface())
+		must(err)
+		state.State[field] = b
+	}
+	b, err := json.Marshal(state)
+	must(err)
+	if string(b) == p.saved {
+		return
+	}
+	if err := setItem(storage, p.key, string(b)); err != nil {
+		vm.comp.warn(fmt.Errorf("persisted state is not saved: %s: %v", p.key, err))
+		return
+	}
+	p.saved = string(b)
+}
+
+// storage returns the web storage of the persistence.
+// Returns false when web storage is unavailable, e.g. disabled by privacy settings.
+func (p *persistence) storage() (js.Value, bool) {
+	name := "localStorage"
+	if p.session {
+		name = "sessionStorage"
+	}
+	storage := js.Global().Get(name)
+	if storage == js.Undefined() || storage == js.Null() {
+		return js.Undefined(), false
+	}
+	return storage, true
+}
+
+// warn logs the error of the component as a warning to the console.
+func (comp *Comp) warn(err error) {
+	js.Global().Get("console").Call("warn", fmt.Sprintf("%s: %v", comp.displayName(), err))
+}
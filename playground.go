@@ -0,0 +1,154 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// maxLog is the maximum number of recent entries kept in the log of the playground.
+const maxLog = 50
+
+// playground mounts registered components in isolation.
+type playground struct {
+	selected string
+	knobs    map[string]interface{}
+	log      []string
+}
+
+// Playground creates a view model on the element which mounts registered components in isolation,
+// e.g. to develop and review components without an app.
+// Components are selected by element, props are edited by knobs
+// and analytics events and errors are logged.
+func Playground(el string) *ViewModel {
+	p := &playground{knobs: make(map[string]interface{})}
+	return New(
+		El(el),
+		Render(p.render),
+		Methods(map[string]func(Context){"Select": p.choose, "Knob": p.knob}),
+		Analytics(p.analytics),
+		Reporter(p.reporter),
+	)
+}
+
+// render renders the components, the knobs of the selected component and the log.
+// Props are bound to the edited knobs, otherwise to their defaults, so required props render.
+func (p *playground) render(context Context) *Node {
+	var buttons []*Node
+	for _, element := range sortedKeys(registry) {
+		buttons = append(buttons, H("button", Attrs{"data-element": element, "v-on:click": "Select"}, element))
+	}
+
+	var mounted *Node
+	var knobs []*Node
+	if comp, ok := registry[p.selected]; ok {
+		for _, prop := range sortedKeys(comp.props) {
+			knobs = append(knobs, p.knobNode(comp, prop))
+			value, ok := p.knobs[prop]
+			if !ok {
+				value = comp.props[prop]
+			}
+			comp.bindProp(prop, value)
+		}
+		mounted = H(p.selected, nil)
+	}
+
+	var log []*Node
+	for _, entry := range p.log {
+		log = append(log, H("li", nil, entry))
+	}
+
+	return H("div", Attrs{"class": "playground"},
+		H("nav", nil, buttons),
+		H("main", nil, mounted),
+		H("aside", nil,
+			H("form", Attrs{"class": "playground-knobs"}, knobs),
+			H("ol", Attrs{"class": "playground-log"}, log),
+		),
+	)
+}
+
+// knobNode renders the knob of the prop by its type.
+// Untyped props are edited as text.
+func (p *playground) knobNode(comp *Comp, prop string) *Node {
+	value, ok := p.knobs[prop]
+	if !ok {
+		value = comp.props[prop]
+	}
+	attrs := Attrs{"data-prop": prop, "v-on:change": "Knob", "type": "text"}
+	if propType, ok := comp.propTypes[prop]; ok {
+		switch propType.typ.Kind() {
+		case reflect.Bool:
+			attrs["type"] = "checkbox"
+			if checked, _ := value.(bool); checked {
+				attrs["checked"] = ""
+			}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+			attrs["type"] = "number"
+		}
+	}
+	if attrs["type"] != "checkbox" && value != nil {
+		attrs["value"] = fmt.Sprintf("%v", value)
+	}
+	return H("label", nil, prop, H("input", attrs))
+}
+
+// choose is the method which selects the component of the target element.
+func (p *playground) choose(context Context) {
+	p.selected = context.Event().Target().GetAttribute("data-element")
+	p.knobs = make(map[string]interface{})
+	p.logf("select: %s", p.selected)
+}
+
+// knob is the method which edits the prop of the target knob.
+func (p *playground) knob(context Context) {
+	event := context.Event()
+	prop := event.Target().GetAttribute("data-prop")
+	var value interface{} = event.Value()
+	if propType, ok := registry[p.selected].propTypes[prop]; ok {
+		value = parseKnob(propType.typ, event)
+	}
+	p.knobs[prop] = value
+	p.logf("knob: %s = %v", prop, value)
+}
+
+// parseKnob parses the value of the knob into the type of the prop.
+func parseKnob(typ reflect.Type, event *Event) interface{} {
+	var value interface{}
+	var err error
+	switch typ.Kind() {
+	case reflect.Bool:
+		value = event.Checked()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err = strconv.ParseInt(event.Value(), 10, 64)
+	case reflect.Float32, reflect.Float64:
+		value, err = strconv.ParseFloat(event.Value(), 64)
+	case reflect.String:
+		value = event.Value()
+	default:
+		err = fmt.Errorf("unsupported knob type: %s", typ)
+	}
+	must(err)
+	return reflect.ValueOf(value).Convert(typ).Interface()
+}
+
+// analytics logs the analytics event.
+func (p *playground) analytics(event AnalyticsEvent) {
+	p.logf("%s: %s", event.Kind, event.Name)
+}
+
+// reporter logs the error of the report.
+func (p *playground) reporter(report Report) {
+	p.logf("error: %v", report.Err)
+}
+
+// logf appends the formatted entry to the log.
+func (p *playground) logf(format string, args ...interface{}) {
+	p.log = append(p.log, fmt.Sprintf(format, args...))
+	if len(p.log) > maxLog {
+		p.log = p.log[len(p.log)-maxLog:]
+	}
+}
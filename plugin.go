@@ -0,0 +1,158 @@
+package vue
+
+import (
+	"sync"
+)
+
+// Plugin extends all components, e.g. a router, a store or translations shipped as separate modules.
+type Plugin interface {
+	Install(in *Installer)
+}
+
+// PluginFunc is a function which is installed as a plugin.
+type PluginFunc func(in *Installer)
+
+// Install calls the function.
+func (fn PluginFunc) Install(in *Installer) {
+	fn(in)
+}
+
+// Installer registers the extensions of plugins globally.
+type Installer struct{}
+
+// globalOptions are the options installed by plugins, which are applied to every component.
+var globalOptions []Option
+
+// globalProvides are the values provided by plugins to all components by key.
+var globalProvides = make(map[string]interface{})
+
+// roots are the root view models, which are rendered by plugins.
+// Roots are guarded by the mutex, since plugins render them from any goroutine, e.g. actions of a store.
+var roots struct {
+	sync.Mutex
+	vms []*ViewModel
+}
+
+// rootViewModels returns a copy of the root view models.
+func rootViewModels() []*ViewModel {
+	roots.Lock()
+	defer roots.Unlock()
+	return append([]*ViewModel(nil), roots.vms...)
+}
+
+// addRoot adds the root view model.
+func addRoot(vm *ViewModel) {
+	roots.Lock()
+	roots.vms = append(roots.vms, vm)
+	roots.Unlock()
+}
+
+// removeRoot removes the root view model, e.g. once unmounted.
+func removeRoot(vm *ViewModel) {
+	roots.Lock()
+	defer roots.Unlock()
+	for i, root := range roots.vms {
+		if root == vm {
+			roots.vms = append(roots.vms[:i], roots.vms[i+1:]...)
+			return
+		}
+	}
+}
+
+// Use installs the plugins in order, which should be called before components are created.
+func Use(plugins ...Plugin) {
+	in := &Installer{}
+	for _, plugin := range plugins {
+		plugin.Install(in)
+	}
+}
+
+// Component registers the component globally by element.
+func (in *Installer) Component(element string, comp *Comp) {
+	Register(element, comp)
+}
+
+// Directive registers the custom directive globally by name.
+func (in *Installer) Directive(name string, directive func(binding *Binding)) {
+	Directive(name, directive)
+}
+
+// Filter registers the filter globally by name.
+func (in *Installer) Filter(name string, filter func(value interface{}, args ...string) interface{}) {
+	Filter(name, filter)
+}
+
+// Option injects the options into every component created afterwards, e.g. Provide of a store.
+// Global options are applied before the options of the component, which take precedence.
+func (in *Installer) Option(options ...Option) {
+	globalOptions = append(globalOptions, options...)
+}
+
+// Provide provides the value by key to all components which inject the key, including root components,
+// e.g. a store. Values provided by components take precedence.
+func (in *Installer) Provide(key string, value interface{}) {
+	globalProvides[key] = value
+}
+
+// ForceUpdate renders all root view models, e.g. after the state of the plugin changes.
+func (in *Installer) ForceUpdate() {
+	for _, vm := range rootViewModels() {
+		vm.ForceUpdate()
+	}
+}
+
+// Update applies the mutation of the state of the plugin before the next render of the root view models,
+// then renders them, e.g. the mutations of a store committed from goroutines.
+// The mutation is applied once, or immediately without root view models.
+func (in *Installer) Update(mutation func()) {
+	vms := rootViewModels()
+	if len(vms) == 0 {
+		mutation()
+		return
+	}
+	var once sync.Once
+	apply := func() {
+		once.Do(mutation)
+		invalidate()
+	}
+	for _, vm := range vms {
+		vm.update(apply)
+	}
+}
+
+// NextTick calls the function after the next render of all root view models,
+// or immediately without root view models.
+func (in *Installer) NextTick(fn func()) {
+	vms := rootViewModels()
+	if len(vms) == 0 {
+		fn()
+		return
+	}
+	// Root view models render in order on the same animation frame.
+	vms[len(vms)-1].NextTick(fn)
+}
+
+// Locale returns the locale of translations, e.g. to detect the locale of a router.
+func (in *Installer) Locale() string {
+	return translationLocale()
+}
+
+// SetLocale sets the locale of translations then renders all root view models,
+// e.g. the locale of the path of a router.
+func (in *Installer) SetLocale(locale string) {
+	setLocale(locale)
+}
+
+// Emit emits the analytics event to the sinks of all root view models, e.g. route events of a router.
+func (in *Installer) Emit(kind, name string) {
+	for _, vm := range rootViewModels() {
+		vm.emit(kind, name)
+	}
+}
+
+// applyGlobal applies the global options to the component.
+func (comp *Comp) applyGlobal() {
+	for _, option := range globalOptions {
+		option(comp)
+	}
+}
@@ -0,0 +1,35 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestInstallerConcurrentRoots(t *testing.T) {
+	in := &Installer{}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				in.Update(func() {})
+				in.Emit(AnalyticsRoute, "/")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				vm := New(El("#app"), Template(`<p>{{ Count }}</p>`), Data(&counter{}))
+				vm.Unmount()
+			}
+		}()
+	}
+	wg.Wait()
+	if vms := rootViewModels(); len(vms) != 0 {
+		t.Errorf("got %d roots, want 0", len(vms))
+	}
+}
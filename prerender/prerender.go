@@ -0,0 +1,225 @@
+// Package prerender renders the routes of a router to static html files, e.g. for content sites deployed to static hosting.
+// Each route is rendered on the server by the application, of which the router-view renders the component of the route,
+// into the page, which loads the wasm application to hydrate the page in the browser:
+//
+//	r := router.New([]router.Route{
+//		{Path: "/", Component: home},
+//		{Path: "/about", Component: about},
+//		{Path: "*", Component: notFound},
+//	}, router.History(""))
+//	vue.Use(r)
+//	app := vue.Component(vue.El("#app"), vue.Template(`<div><router-view></router-view></div>`), vue.Hydrate())
+//	err := prerender.Render("public", app, r, prerender.Title("/about", "About"), prerender.Wasm("main.wasm"))
+//
+// Routes are written to the index.html file of their directory, e.g. /about to public/about/index.html,
+// which static hosts serve by the clean urls of the history mode of the router.
+// The route of the * path is written to 404.html, while paths with params are skipped, since their params are unknown.
+// The package has no js dependencies, so it is imported by build tools.
+package prerender
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/norunners/vue"
+	"github.com/norunners/vue/loader"
+	"github.com/norunners/vue/router"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Names of the files of the wasm bootstrap, which are loaded from the root of the site.
+const (
+	wasmFile = "main.wasm"
+	execFile = "wasm_exec.js"
+)
+
+// defaultPage is the page of routes, the application renders into the app element.
+const defaultPage = `<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+{{ if .Title }}<title>{{ .Title }}</title>{{ end }}{{ .Head }}
+</head>
+<body>
+<div id="app" data-vue-state="{{ .State }}">{{ .App }}</div>
+{{ .Loader }}
+</body>
+</html>
+`
+
+// Page is the data of the page template.
+// The head is the html of the head elements of the component, the app is the html of the rendered component,
+// the state is the json of its data for the data-vue-state attribute of the app element
+// and the loader is the html which loads the application.
+type Page struct {
+	Path   string
+	Title  string
+	Head   template.HTML
+	App    template.HTML
+	State  string
+	Loader template.HTML
+}
+
+// Option is an option of the prerender.
+type Option func(*site)
+
+// site renders the routes into a directory.
+type site struct {
+	page    *template.Template
+	wasm    string
+	exec    string
+	loaders []loader.Option
+	titles  map[string]string
+	data    map[string]interface{}
+}
+
+// Title is the title of the page of the route path, unless the components render the title by their head.
+func Title(path, title string) Option {
+	return func(s *site) {
+		s.titles[path] = title
+	}
+}
+
+// Data replaces the data of the application for the page of the route path, e.g. the content of the page.
+func Data(path string, data interface{}) Option {
+	return func(s *site) {
+		s.data[path] = data
+	}
+}
+
+// Template is the html template of pages executed with Page, e.g. to include styles and meta tags.
+// The app must be rendered into the element of the el option of the component,
+// of which the data-vue-state attribute is the state, e.g. <div id="app" data-vue-state="{{ .State }}">.
+// By default, the app renders into the element of the app id.
+func Template(page string) Option {
+	return func(s *site) {
+		s.page = template.Must(template.New("page").Parse(page))
+	}
+}
+
+// Wasm is the path of the wasm binary of the application, which is copied to the site with the wasm_exec.js
+// support script of the Go distribution. Without the binary, the pages are static.
+func Wasm(path string) Option {
+	return func(s *site) {
+		s.wasm = path
+	}
+}
+
+// Exec is the path of the wasm_exec.js support script, from the Go distribution by default.
+func Exec(path string) Option {
+	return func(s *site) {
+		s.exec = path
+	}
+}
+
+// Loader are the options of the loader of the application, e.g. the url of failure reports.
+func Loader(options ...loader.Option) Option {
+	return func(s *site) {
+		s.loaders = append(s.loaders, options...)
+	}
+}
+
+// Render renders the routes of the installed router by the application to html files in the directory,
+// including the wasm bootstrap. The router routes each path before the application renders.
+func Render(dir string, app *vue.Comp, r *router.Router, options ...Option) error {
+	s := &site{
+		page:   template.Must(template.New("page").Parse(defaultPage)),
+		titles: make(map[string]string),
+		data:   make(map[string]interface{}),
+	}
+	for _, option := range options {
+		option(s)
+	}
+
+	for _, path := range r.Paths() {
+		file, ok := routeFile(path)
+		if !ok {
+			continue
+		}
+		r.Push(path)
+		if err := s.render(filepath.Join(dir, file), path, app); err != nil {
+			return fmt.Errorf("prerender %s: %v", path, err)
+		}
+	}
+	if s.wasm == "" {
+		return nil
+	}
+	if err := copyFile(s.wasm, filepath.Join(dir, wasmFile)); err != nil {
+		return err
+	}
+	if s.exec == "" {
+		s.exec = execPath()
+	}
+	return copyFile(s.exec, filepath.Join(dir, execFile))
+}
+
+// render renders the application of the routed path into the file.
+func (s *site) render(file, path string, comp *vue.Comp) error {
+	app, head, state, err := vue.RenderWithState(comp, s.data[path])
+	if err != nil {
+		return err
+	}
+
+	page := Page{Path: path, Title: s.titles[path], Head: template.HTML(head), App: template.HTML(app), State: state}
+	if strings.Contains(head, "<title>") {
+		page.Title = ""
+	}
+	if s.wasm != "" {
+		// The bootstrap is loaded from the root, since pages are nested in directories.
+		// Pages are not replaced by the fallback, because their content is rendered.
+		options := append([]loader.Option{loader.Wasm("/" + wasmFile), loader.Exec("/" + execFile), loader.Fallback("")}, s.loaders...)
+		page.Loader = template.HTML(loader.HTML(options...))
+	}
+	var b bytes.Buffer
+	if err := s.page.Execute(&b, page); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, b.Bytes(), 0644)
+}
+
+// routeFile returns the file of the route path relative to the site.
+// Returns false for paths with params.
+func routeFile(routePath string) (string, bool) {
+	if routePath == "*" {
+		return "404.html", true
+	}
+	clean := path.Clean("/" + routePath)
+	for _, segment := range strings.Split(clean, "/") {
+		if strings.HasPrefix(segment, ":") || strings.Contains(segment, "*") {
+			return "", false
+		}
+	}
+	return filepath.Join(filepath.FromSlash(clean), "index.html"), true
+}
+
+// execPath returns the path of the wasm_exec.js support script of the Go distribution.
+func execPath() string {
+	for _, dir := range []string{"misc", "lib"} {
+		path := filepath.Join(runtime.GOROOT(), dir, "wasm", execFile)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(runtime.GOROOT(), "misc", "wasm", execFile)
+}
+
+// copyFile copies the file from the source to the destination.
+func copyFile(src, dst string) error {
+	b, err := ioutil.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(dst, b, 0644)
+}
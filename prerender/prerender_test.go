@@ -0,0 +1,62 @@
+package prerender
+
+import (
+	"github.com/norunners/vue"
+	"github.com/norunners/vue/router"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type page struct {
+	Heading string
+}
+
+func TestRender(t *testing.T) {
+	r := router.New([]router.Route{
+		{Path: "/", Component: vue.Component(vue.Template(`<p>home</p>`))},
+		{Path: "/about", Component: vue.Component(vue.Template(`<p>about</p>`))},
+		{Path: "/users/:id", Component: vue.Component(vue.Template(`<p>user</p>`))},
+		{Path: "*", Component: vue.Component(vue.Template(`<p>not found</p>`))},
+	}, router.History(""))
+	vue.Use(r)
+	app := vue.Component(
+		vue.Template(`<div><h1>{{ Heading }}</h1><router-link to="/about">About</router-link><router-view></router-view></div>`),
+		vue.Data(&page{Heading: "site"}),
+	)
+
+	dir, err := ioutil.TempDir("", "prerender")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := Render(dir, app, r, Title("/about", "About"), Data("/about", &page{Heading: "about us"})); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		file     string
+		contains []string
+	}{
+		{"index.html", []string{`<h1>site</h1>`, `<p>home</p>`, `href="/about"`, `data-vue-state="{&#34;Heading&#34;:&#34;site&#34;}"`}},
+		{"about/index.html", []string{`<title>About</title>`, `<h1>about us</h1>`, `<p>about</p>`, `router-link-exact-active`}},
+		{"404.html", []string{`<p>not found</p>`}},
+	}
+	for _, test := range tests {
+		b, err := ioutil.ReadFile(filepath.Join(dir, test.file))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		for _, want := range test.contains {
+			if !strings.Contains(string(b), want) {
+				t.Errorf("%s does not contain %s: %s", test.file, want, b)
+			}
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "users")); !os.IsNotExist(err) {
+		t.Errorf("path with params was prerendered: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+	"time"
+)
+
+// Profile is the startup profile of a root view model.
+type Profile struct {
+	// Options is the time spent processing options.
+	Options time.Duration
+	// Compile is the time spent compiling templates during the first render.
+	Compile time.Duration
+	// FirstRender is the time spent on the first render, including compiling templates.
+	FirstRender time.Duration
+}
+
+// compileTime is the total time spent compiling templates in nanoseconds, which is added atomically.
+var compileTime int64
+
+// Profile returns the startup profile of the view model, e.g. to log the startup of large apps.
+func (vm *ViewModel) Profile() Profile {
+	return vm.profile
+}
+
+// LazyMount is the lazy mount option for subcomponents.
+// Mounting is deferred until after the first paint, so non-critical components don't delay it.
+// The subcomponent renders empty until mounted.
+func LazyMount() Option {
+	return func(sub *Comp) {
+		sub.lazy = true
+	}
+}
+
+// deferMount returns an empty node and schedules the mount of a lazy subcomponent after the next paint.
+// Returns false once the subcomponent is mounted.
+func (sub *Comp) deferMount() (*html.Node, bool) {
+	if !sub.lazy || sub.lazyMounted {
+		return nil, false
+	}
+	if !sub.lazyScheduled {
+		sub.lazyScheduled = true
+		// The animation frame precedes the paint, so the mount follows by a timer.
+		requestAnimationFrame(func() {
+			time.AfterFunc(0, func() {
+				sub.callback.update(func() {
+					sub.lazyMounted = true
+				})
+			})
+		})
+	}
+	return &html.Node{Type: html.ElementNode}, true
+}
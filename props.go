@@ -0,0 +1,172 @@
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// propType is the declaration of a typed prop.
+type propType struct {
+	typ      reflect.Type
+	def      interface{}
+	required bool
+}
+
+// PropTypes is the typed props option for subcomponents.
+// The exported fields of the given struct declare the props by name and type, named by the vue tag.
+// The values of the fields are the defaults of props which are not bound by the parent.
+// Props are required by the prop tag, e.g. `prop:"required"`.
+func PropTypes(props interface{}) Option {
+	return func(sub *Comp) {
+		value := reflect.Indirect(reflect.ValueOf(props))
+		if value.Kind() != reflect.Struct {
+			must(fmt.Errorf("props is not of kind struct: %T", props))
+		}
+		typ := value.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			required := strings.Contains(field.Tag.Get("prop"), "required")
+			def := value.Field(i).Interface()
+			name := fieldName(field)
+			sub.props[name] = def
+			sub.propTypes[name] = propType{typ: field.Type, def: def, required: required}
+		}
+	}
+}
+
+// bindProp binds the value to the prop of the subcomponent.
+// Typed props must be bound to values assignable to their type.
+func (sub *Comp) bindProp(prop string, value interface{}) {
+	if propType, ok := sub.propTypes[prop]; ok && value != nil {
+		if typ := reflect.TypeOf(value); !typ.AssignableTo(propType.typ) {
+			must(fmt.Errorf("prop %s is not of type %s: %s", prop, propType.typ, typ))
+		}
+	}
+	sub.props[prop] = value
+	sub.bound[prop] = struct{}{}
+}
+
+// propValue returns the original value of the path for typed props which are not assignable from the mapped value,
+// since data is mapped with nested structs as maps, e.g. a struct prop bound to a field of the data.
+func (tmpl *template) propValue(sub *Comp, prop, path string, value interface{}) interface{} {
+	propType, ok := sub.propTypes[prop]
+	if !ok || value == nil || reflect.TypeOf(value).AssignableTo(propType.typ) {
+		return value
+	}
+	if val, ok := tmpl.originalValue(path); ok && val.CanInterface() && val.Type().AssignableTo(propType.typ) {
+		return val.Interface()
+	}
+	return value
+}
+
+// originalValue returns the unmapped value of the dotted path
+// from the loop variables of the template or the data of the component.
+func (tmpl *template) originalValue(path string) (reflect.Value, bool) {
+	names := strings.Split(path, ".")
+	if val, ok := tmpl.originals[names[0]]; ok {
+		return readPath(val, names[1:])
+	}
+	datas := append([]interface{}{tmpl.comp.data}, tmpl.comp.mixins...)
+	for _, data := range datas {
+		if val, ok := readPath(reflect.ValueOf(data), names); ok {
+			return val, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// readPath reads the value of the names through structs, pointers and maps.
+// Unlike walkPath, nil pointers are not allocated.
+func readPath(val reflect.Value, names []string) (reflect.Value, bool) {
+	for _, name := range names {
+		for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return reflect.Value{}, false
+			}
+			val = val.Elem()
+		}
+		switch val.Kind() {
+		case reflect.Struct:
+			field, ok := structField(val.Type(), name)
+			if !ok {
+				return reflect.Value{}, false
+			}
+			if val, ok = fieldByIndex(val, field.Index); !ok {
+				return reflect.Value{}, false
+			}
+		case reflect.Map:
+			if val.Type().Key().Kind() != reflect.String {
+				return reflect.Value{}, false
+			}
+			val = val.MapIndex(reflect.ValueOf(name).Convert(val.Type().Key()))
+		default:
+			return reflect.Value{}, false
+		}
+	}
+	return val, val.IsValid()
+}
+
+// bindStaticProps binds the static attributes of the element to the matching props,
+// e.g. <app-icon name="home">, unless bound by the parent.
+// Static attributes bind untyped, string and int props, which are removed from the attributes of the element.
+// Int props are parsed from the attribute, e.g. <user-card id="42">.
+func (sub *Comp) bindStaticProps(node *html.Node) {
+	attrs := node.Attr[:0]
+	for _, attr := range node.Attr {
+		prop, ok := sub.propName(attr.Key)
+		if !ok {
+			attrs = append(attrs, attr)
+			continue
+		}
+		value, ok := sub.staticProp(prop, attr.Val)
+		if !ok {
+			attrs = append(attrs, attr)
+			continue
+		}
+		if _, bound := sub.bound[prop]; !bound {
+			sub.bindProp(prop, value)
+		}
+	}
+	node.Attr = attrs
+}
+
+// staticProp converts the text of the static attribute to the type of the prop.
+// Returns false for types other than strings and ints.
+func (sub *Comp) staticProp(prop, text string) (interface{}, bool) {
+	propType, typed := sub.propTypes[prop]
+	if !typed {
+		return text, true
+	}
+	switch propType.typ.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(text).Convert(propType.typ).Interface(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, propType.typ.Bits())
+		if err != nil {
+			must(fmt.Errorf("prop %s is not of type %s: %q", prop, propType.typ, text))
+		}
+		return reflect.ValueOf(n).Convert(propType.typ).Interface(), true
+	default:
+		return nil, false
+	}
+}
+
+// checkProps checks required props were bound then resets unbound typed props to defaults.
+func (sub *Comp) checkProps() {
+	for prop, propType := range sub.propTypes {
+		if _, ok := sub.bound[prop]; ok {
+			continue
+		}
+		if propType.required {
+			must(fmt.Errorf("missing required prop: %s", prop))
+		}
+		sub.props[prop] = propType.def
+	}
+	sub.bound = make(map[string]struct{}, len(sub.bound))
+}
@@ -0,0 +1,46 @@
+package vue
+
+import (
+	"fmt"
+)
+
+// Provide is the provide option for components.
+// The value is provided by key to all descendant subcomponents which inject the key,
+// e.g. a theme, api client or store.
+func Provide(key string, value interface{}) Option {
+	return func(comp *Comp) {
+		comp.provides[key] = value
+	}
+}
+
+// Inject is the inject option for components.
+// The values provided by the closest ancestor are mapped to data by key.
+// Root components inject the values provided by plugins.
+func Inject(keys ...string) Option {
+	return func(sub *Comp) {
+		sub.injects = append(sub.injects, keys...)
+	}
+}
+
+// inject maps injected values to data.
+func (vm *ViewModel) inject() {
+	for _, key := range vm.comp.injects {
+		value, ok := vm.comp.parent.provided(key)
+		if !ok {
+			must(fmt.Errorf("unknown injection: %s", key))
+		}
+		vm.data[key] = value
+	}
+}
+
+// provided returns the value provided by the closest component, otherwise by plugins.
+// Nil components have only the values provided by plugins.
+func (comp *Comp) provided(key string) (interface{}, bool) {
+	for ; comp != nil; comp = comp.parent {
+		if value, ok := comp.provides[key]; ok {
+			return value, true
+		}
+	}
+	value, ok := globalProvides[key]
+	return value, ok
+}
@@ -0,0 +1,83 @@
+package vue
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Query is the query option for root components.
+// The data fields are bound to the parameters of the query string of the url by name, e.g. filters, pages or tabs.
+// Fields are initialized from the url when the component is created, then the url is replaced after renders which change them.
+// Navigating back and forward restores the fields from the url then renders.
+// Fields of zero values are omitted from the url.
+// Fields are strings, bools, numbers or slices of strings, which are named like in templates by dotted paths.
+func Query(fields ...string) Option {
+	return func(comp *Comp) {
+		comp.query = append(comp.query, fields...)
+	}
+}
+
+// setQueryValue sets the field by the values of the query parameter, or to the zero value without values.
+func setQueryValue(val reflect.Value, values []string) error {
+	if len(values) == 0 {
+		val.Set(reflect.Zero(val.Type()))
+		return nil
+	}
+	value := values[0]
+	switch val.Kind() {
+	case reflect.String:
+		val.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		val.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, val.Type().Bits())
+		if err != nil {
+			return err
+		}
+		val.SetFloat(f)
+	case reflect.Slice:
+		if val.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type: %s", val.Type())
+		}
+		slice := reflect.MakeSlice(val.Type(), len(values), len(values))
+		for i, v := range values {
+			slice.Index(i).SetString(v)
+		}
+		val.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type: %s", val.Type())
+	}
+	return nil
+}
+
+// queryValues returns the values of the query parameter of the field, which are empty for zero values.
+func queryValues(val reflect.Value) []string {
+	if val.IsZero() {
+		return nil
+	}
+	if val.Kind() == reflect.Slice {
+		values := make([]string, val.Len())
+		for i := range values {
+			values[i] = fmt.Sprint(val.Index(i).Interface())
+		}
+		return values
+	}
+	return []string{fmt.Sprint(val.Interface())}
+}
@@ -0,0 +1,74 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"syscall/js"
+)
+
+// watchQuery initializes the query fields from the url then restores them on navigation.
+func (vm *ViewModel) watchQuery() {
+	if len(vm.comp.query) == 0 || vm.comp.isSub {
+		return
+	}
+	vm.readQuery()
+	unlisten := listen(js.Global(), "popstate", func(js.Value) {
+		vm.readQuery()
+		vm.render()
+	})
+	vm.unlisten = append(vm.unlisten, unlisten)
+}
+
+// readQuery sets the query fields from the query string of the url.
+// Fields absent from the url are reset to zero values.
+func (vm *ViewModel) readQuery() {
+	search := js.Global().Get("location").Get("search").String()
+	values, err := url.ParseQuery(strings.TrimPrefix(search, "?"))
+	if err != nil {
+		vm.comp.warn(fmt.Errorf("invalid query: %v", err))
+		return
+	}
+	for _, field := range vm.comp.query {
+		val, ok := vm.comp.dataField(field)
+		if !ok || !val.CanSet() {
+			must(fmt.Errorf("unknown data field: %s", field))
+		}
+		if err := setQueryValue(val, values[field]); err != nil {
+			vm.comp.warn(fmt.Errorf("invalid query parameter %s: %v", field, err))
+		}
+	}
+	vm.comp.queried = values.Encode()
+}
+
+// writeQuery replaces the query string of the url by the query fields unless unchanged.
+// Other parameters of the url are kept.
+func (vm *ViewModel) writeQuery() {
+	if len(vm.comp.query) == 0 {
+		return
+	}
+	location := js.Global().Get("location")
+	u, err := url.Parse(location.Get("href").String())
+	must(err)
+	values := u.Query()
+	for _, field := range vm.comp.query {
+		val, ok := vm.comp.dataField(field)
+		if !ok {
+			must(fmt.Errorf("unknown data field: %s", field))
+		}
+		values.Del(field)
+		for _, value := range queryValues(val) {
+			values.Add(field, value)
+		}
+	}
+	query := values.Encode()
+	if query == vm.comp.queried {
+		return
+	}
+	vm.comp.queried = query
+	u.RawQuery = query
+	js.Global().Get("history").Call("replaceState", js.Global().Get("history").Get("state"), "", u.String())
+}
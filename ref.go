@@ -0,0 +1,20 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+)
+
+// Attributes of referenced elements, e.g. <input ref="input">.
+// Rendered refs are scoped by the instance of their component,
+// so refs of repeated or nested components and teleported elements do not collide.
+const (
+	refAttr      = "ref"
+	refScopeAttr = "data-v-ref"
+)
+
+// scopeRef adds the scope of the component to the node of a ref.
+func (comp *Comp) scopeRef(node *html.Node) {
+	if hasAttr(node, refAttr) && !hasAttr(node, refScopeAttr) {
+		node.Attr = append(node.Attr, html.Attribute{Key: refScopeAttr, Val: comp.instanceScope()})
+	}
+}
@@ -0,0 +1,31 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// Ref returns the rendered element by the name of its ref attribute, e.g. to focus an input,
+// measure its bounding box or pass it to a chart library.
+// Refs are scoped by the instance of the component, including teleported elements,
+// where the first element of the name is returned, e.g. of a for attribute.
+// Returns null when no element of the ref is rendered.
+func (vm *ViewModel) Ref(name string) Value {
+	escaped := js.Global().Get("CSS").Call("escape", name).String()
+	selector := fmt.Sprintf(`[%s="%s"][%s="%s"]`, refScopeAttr, vm.comp.instanceScope(), refAttr, escaped)
+	return js.Global().Get("document").Call("querySelector", selector)
+}
+
+// El returns the mounted root element of the template within the element of the el option,
+// e.g. to attach observers or pass it to javascript libraries.
+// Fragments return their first root element.
+// Returns null for subcomponents and components without the el option.
+func (vm *ViewModel) El() js.Value {
+	if vm.comp.isSub || vm.comp.el == nil {
+		return js.Null()
+	}
+	return vm.comp.el.Underlying().Get("firstElementChild")
+}
@@ -0,0 +1,185 @@
+package vue
+
+import (
+	"bytes"
+	"encoding/json"
+	"golang.org/x/net/html"
+	"regexp"
+	"strconv"
+	"sync/atomic"
+)
+
+// Renders only re-execute the regions of templates which read data fields that changed.
+// Regions are the subtrees of the template below its plain elements, e.g. the header, list and footer of
+// <div id="app"><header>{{ Title }}</header><ul><li v-for="todo in Todos">{{ todo }}</li></ul><footer>...</footer></div>,
+// where the plain div and ul are entered and each of their children is a region, including texts.
+// The dependencies of a region are the data fields named by its template, i.e. by its directives and interpolations,
+// including props, injections and computed. The json of the dependencies is kept with the executed nodes of the region,
+// which are reused while the json is unchanged. Values which do not encode as json are always executed.
+// Regions which render subcomponents, e.g. by their elements, keep-alive and error boundaries, or custom directives
+// are executed each render, since their executions have effects, then the regions of the subcomponents are reused.
+// Plugins invalidate all regions when their state changes, e.g. translations and feature flags, as does ForceUpdate.
+
+// epoch is incremented atomically to invalidate all regions.
+var epoch int64
+
+// invalidate invalidates all regions, e.g. when state outside of components changes.
+func invalidate() {
+	atomic.AddInt64(&epoch, 1)
+}
+
+// region is the execution of a region of a template.
+type region struct {
+	names []string
+	deps  map[string]string
+	nodes []*html.Node
+	epoch int64
+}
+
+// regionName matches the names of a region which may be data fields, e.g. Todos of v-for="todo in Todos".
+var regionName = regexp.MustCompile(`[$\w]+`)
+
+// executeRegions executes the children of the parent, each as a region, and enters the plain children.
+// Regions are keyed by their path in the template, which is stable across renders.
+func (tmpl *template) executeRegions(parent *html.Node, path string, data map[string]interface{}) {
+	i := 0
+	for child := parent.FirstChild; child != nil; i++ {
+		key := path + "/" + strconv.Itoa(i)
+		if child.Type == html.ElementNode && tmpl.plain(child) && hasElements(child) {
+			tmpl.comp.scopeRef(child)
+			tmpl.executeRegions(child, key, data)
+			child = child.NextSibling
+			continue
+		}
+		child = tmpl.executeRegion(child, key, data)
+	}
+}
+
+// executeRegion executes the node as the region of the key, or reuses the nodes of its previous execution
+// when its dependencies are unchanged. Returns the next node after the region.
+func (tmpl *template) executeRegion(node *html.Node, key string, data map[string]interface{}) *html.Node {
+	parent, prev, next := node.Parent, node.PrevSibling, node.NextSibling
+	r, ok := tmpl.comp.regions[key]
+	if ok && r.current(data) {
+		for _, reused := range r.nodes {
+			parent.InsertBefore(cloneNode(reused), node)
+		}
+		parent.RemoveChild(node)
+		return next
+	}
+	if !ok {
+		r = &region{names: regionNames(node)}
+	}
+
+	effects := tmpl.effects
+	for child := node; child != next; {
+		child = tmpl.executeElement(child, data)
+	}
+	start := parent.FirstChild
+	if prev != nil {
+		start = prev.NextSibling
+	}
+	for _, executed := range siblings(start, next) {
+		tmpl.executeText(executed, data)
+	}
+	if tmpl.effects != effects {
+		delete(tmpl.comp.regions, key)
+		return next
+	}
+
+	r.record(data, siblings(start, next))
+	if tmpl.comp.regions == nil {
+		tmpl.comp.regions = make(map[string]*region)
+	}
+	tmpl.comp.regions[key] = r
+	return next
+}
+
+// record records the dependencies of the executed nodes of the region.
+func (r *region) record(data map[string]interface{}, nodes []*html.Node) {
+	r.epoch = atomic.LoadInt64(&epoch)
+	r.deps = make(map[string]string)
+	for _, name := range r.names {
+		value, ok := data[name]
+		if !ok {
+			continue
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			r.epoch = -1
+			return
+		}
+		r.deps[name] = string(b)
+	}
+	r.nodes = make([]*html.Node, len(nodes))
+	for i, node := range nodes {
+		r.nodes[i] = cloneNode(node)
+	}
+}
+
+// current determines if the dependencies of the region are unchanged since its execution.
+func (r *region) current(data map[string]interface{}) bool {
+	if r.epoch != atomic.LoadInt64(&epoch) {
+		return false
+	}
+	for _, name := range r.names {
+		value, ok := data[name]
+		dep, had := r.deps[name]
+		if ok != had {
+			return false
+		}
+		if !ok {
+			continue
+		}
+		b, err := json.Marshal(value)
+		if err != nil || string(b) != dep {
+			return false
+		}
+	}
+	return true
+}
+
+// regionNames returns the distinct names of the template of the region.
+func regionNames(node *html.Node) []string {
+	var b bytes.Buffer
+	must(html.Render(&b, node))
+	seen := make(map[string]struct{})
+	var names []string
+	for _, name := range regionName.FindAllString(b.String(), -1) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// hasElements determines if the node has child elements.
+func hasElements(node *html.Node) bool {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode {
+			return true
+		}
+	}
+	return false
+}
+
+// renders returns the count of renders of the root view model of the component.
+// Each root counts its own renders, e.g. concurrent headless renders on the server.
+func (comp *Comp) renders() int {
+	if root := comp.root(); root.vm != nil {
+		return root.vm.renders
+	}
+	return 0
+}
+
+// cloneNode recursively clones the html node.
+func cloneNode(node *html.Node) *html.Node {
+	clone := &html.Node{Type: node.Type, DataAtom: node.DataAtom, Data: node.Data, Namespace: node.Namespace}
+	clone.Attr = make([]html.Attribute, len(node.Attr))
+	copy(clone.Attr, node.Attr)
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		clone.AppendChild(cloneNode(child))
+	}
+	return clone
+}
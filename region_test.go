@@ -0,0 +1,85 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"strings"
+	"testing"
+)
+
+type regionData struct {
+	Title string
+	Todos []string
+	Count int
+}
+
+func TestRegions(t *testing.T) {
+	executions := make(map[string]int)
+	Filter("counted", func(value interface{}, args ...string) interface{} {
+		executions[args[0]]++
+		return value
+	})
+	comp := Component(
+		Template(`<div>
+			<h1>{{ Title | counted "title" }}</h1>
+			<ul><li v-for="todo in Todos">{{ todo | counted "item" }}</li></ul>
+			<p>{{ Count | counted "count" }}</p>
+		</div>`),
+		Data(&regionData{Title: "todos", Todos: []string{"milk"}}),
+	)
+	vm := Headless(comp, nil)
+	want := map[string]int{"title": 1, "item": 1, "count": 1}
+	check := func(step string) {
+		t.Helper()
+		for name, n := range want {
+			if executions[name] != n {
+				t.Errorf("%s: got %d executions of %s, want %d", step, executions[name], name, n)
+			}
+		}
+	}
+	check("first render")
+
+	vm.Set("Count", 1)
+	vm.render()
+	want["count"]++
+	check("set count")
+
+	vm.Push("Todos", "eggs")
+	want["item"] += 2
+	check("push todo")
+
+	vm.Data().(*regionData).Todos[0] = "bread"
+	vm.ForceUpdate()
+	want["title"]++
+	want["item"] += 2
+	want["count"]++
+	check("force update")
+
+	vm.Data().(*regionData).Title = "shopping"
+	vm.render()
+	want["title"]++
+	check("mutate title")
+
+	if got, want := vm.HTML(), "<h1>shopping</h1>"; !strings.Contains(got, want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestRegionsOfSubcomponents(t *testing.T) {
+	counter := Component(
+		Template(`<span>{{ Count }}</span>`),
+		Props("Count"),
+	)
+	comp := Component(
+		Template(`<div><h1>{{ Title }}</h1><counter v-bind:Count="Count"></counter></div>`),
+		Data(&regionData{Title: "counter"}),
+		Sub("counter", counter),
+	)
+	vm := Headless(comp, nil)
+	vm.Set("Count", 2)
+	vm.render()
+	if got, want := vm.HTML(), "<div><h1>counter</h1><span>2</span></div>"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -4,25 +4,60 @@ import (
 	"golang.org/x/net/html"
 )
 
-// render renders the prepared data.
-// Subcomponents use the callback to render the root element.
-func (vm *ViewModel) render() {
-	if vm.comp.isSub {
-		if vm.executed {
-			vm.comp.callback.render()
-		}
-		return
+// ForceUpdate renders the component and its subcomponents on the next animation frame,
+// e.g. after data is mutated outside of methods by a goroutine receiving on a channel.
+func (vm *ViewModel) ForceUpdate() {
+	invalidate()
+	vm.render()
+}
+
+// Updated is the updated hook option for root components.
+// The function is called after each render updates the dom, e.g. to persist data.
+func Updated(fn func(Context)) Option {
+	return func(comp *Comp) {
+		comp.updated = append(comp.updated, fn)
+	}
+}
+
+// tick calls the lifecycle and updated hooks then the functions queued for after the render.
+func (vm *ViewModel) tick() {
+	lifecycle := vm.lifecycle
+	vm.lifecycle = nil
+	for _, hook := range lifecycle {
+		hook()
 	}
+	for _, updated := range vm.comp.updated {
+		updated(vm)
+	}
+	ticks := vm.ticks
+	vm.ticks = nil
+	for _, tick := range ticks {
+		tick()
+	}
+}
+
+// execute executes the subcomponent into a node.
+// Functional components render without a view model.
+// Subcomponents reuse the regions of their template which are unchanged.
+// Throttled subcomponents reuse the previous node within their interval.
+// Lazy subcomponents render empty until mounted.
+func (sub *Comp) execute() *html.Node {
+	if node, ok := sub.deferMount(); ok {
+		return node
+	}
+	return sub.executeThrottled(func() *html.Node {
+		if sub.functional != nil {
+			return parseNode(sub.functional(sub.props))
+		}
 
-	vm.mapData()
-	node := vm.tmpl.execute(vm.data)
-	vm.vnode.render(node)
+		vm := newViewModel(sub)
+		vm.mapData()
+		vm.executed = true
+		return vm.executeSub()
+	})
 }
 
-// executeSub executes the subcomponent into a node.
+// executeSub executes the mapped data of the subcomponent into a node.
 func (vm *ViewModel) executeSub() *html.Node {
-	vm.mapData()
-	node := vm.tmpl.execute(vm.data)
-	vm.executed = true
-	return node
+	return vm.tmpl.execute(vm, vm.data)
 }
@@ -0,0 +1,71 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"time"
+)
+
+// render schedules the prepared data to render on the next animation frame.
+// Renders are batched, so multiple mutations before the next frame render once.
+// Subcomponents use the callback to render the root element.
+func (vm *ViewModel) render() {
+	if vm.comp.isSub {
+		if vm.executed {
+			vm.comp.callback.render()
+		}
+		return
+	}
+
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	if vm.scheduled || vm.unmounted {
+		return
+	}
+	vm.scheduled = true
+	if wait := vm.throttleWait(); wait > 0 {
+		time.AfterFunc(wait, func() {
+			requestAnimationFrame(vm.flush)
+		})
+		return
+	}
+	requestAnimationFrame(vm.flush)
+}
+
+// flush renders the prepared data immediately.
+// Queued updates are applied before rendering.
+// Renders are postponed while the patches of a concurrent diff are pending.
+func (vm *ViewModel) flush() {
+	defer vm.report()
+	vm.mu.Lock()
+	vm.scheduled = false
+	vm.flushed = time.Now()
+	if vm.unmounted {
+		vm.mu.Unlock()
+		return
+	}
+	if vm.patching {
+		vm.pending = true
+		vm.mu.Unlock()
+		return
+	}
+	vm.mu.Unlock()
+	vm.renders++
+
+	vm.applyUpdates()
+	vm.savePersisted()
+	vm.writeQuery()
+
+	vm.mapData()
+	node := vm.tmpl.execute(vm, vm.data)
+	vm.unmountSubs()
+	if vm.comp.concurrentDiff {
+		vm.diff(node)
+		return
+	}
+	state := captureView()
+	vm.renderNode(node)
+	state.restore()
+	vm.tick()
+}
@@ -0,0 +1,87 @@
+package vue
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// maxBreadcrumbs is the maximum number of recent breadcrumbs kept for reports.
+const maxBreadcrumbs = 20
+
+// Report is an error captured from rendering or an event handler.
+type Report struct {
+	Err         error
+	Context     Context
+	Stack       []byte
+	Breadcrumbs []string
+}
+
+// Reporter is the reporter option for components.
+// Panics from renders and event handlers are recovered and forwarded to the reporter,
+// e.g. to an error tracking service by js interop.
+// Without a reporter, panics are not recovered.
+func Reporter(reporter func(report Report)) Option {
+	return func(comp *Comp) {
+		comp.reporter = reporter
+	}
+}
+
+// report recovers a panic and forwards it to the reporter of the component.
+// Report must be deferred directly to recover.
+func (vm *ViewModel) report() {
+	if !vm.handlesErr() {
+		return
+	}
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	err, ok := r.(error)
+	if !ok {
+		err = fmt.Errorf("%v", r)
+	}
+	vm.reportErr(err)
+}
+
+// reportErr forwards the error to the error handler and the reporter of the component
+// then renders the fallback. Without any of them, the error panics.
+// Errors of subcomponents are handled with the context of the subcomponent.
+func (vm *ViewModel) reportErr(err error) {
+	if !vm.handlesErr() {
+		must(err)
+	}
+	context := Context(vm)
+	if sub, ok := err.(*subErr); ok {
+		err, context = sub.err, sub.context
+	}
+
+	if errorHandler != nil {
+		errorHandler(err, context)
+	}
+	if vm.hasFallback() {
+		vm.renderFallback(err)
+	}
+	if vm.comp.reporter == nil {
+		return
+	}
+	breadcrumbs := make([]string, len(vm.breadcrumbs))
+	copy(breadcrumbs, vm.breadcrumbs)
+	vm.comp.reporter(Report{Err: err, Context: context, Stack: debug.Stack(), Breadcrumbs: breadcrumbs})
+}
+
+// handlesErr determines if the view model handles errors instead of panicking.
+func (vm *ViewModel) handlesErr() bool {
+	return errorHandler != nil || vm.comp.reporter != nil || vm.hasFallback()
+}
+
+// breadcrumb records a recent event or mutation for reports.
+func (vm *ViewModel) breadcrumb(format string, args ...interface{}) {
+	if vm.comp.reporter == nil {
+		return
+	}
+	if len(vm.breadcrumbs) == maxBreadcrumbs {
+		vm.breadcrumbs = vm.breadcrumbs[1:]
+	}
+	vm.breadcrumbs = append(vm.breadcrumbs, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,109 @@
+package vue
+
+import (
+	"bytes"
+	"fmt"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"strings"
+)
+
+const contentEditable = "contenteditable"
+
+// richTextTmpl is the template of the rich text component.
+// The toolbar formats the selection of the editor, which notifies the content of input.
+// Buttons prevent the default action of mousedown, so the editor keeps the selection.
+// Styles refer to the tokens of the theme, e.g. --vue-rich-text-border, with defaults.
+const richTextTmpl = `
+<div class="rich-text" style="border: var(--vue-rich-text-border, 1px solid #ccc)">
+  <div class="rich-text-toolbar" style="background: var(--vue-rich-text-toolbar, #f5f5f5)">
+    <button type="button" v-on:mousedown.prevent="Bold"><b>B</b></button>
+    <button type="button" v-on:mousedown.prevent="Italic"><i>I</i></button>
+    <button type="button" v-on:mousedown.prevent="BulletList">&bull;</button>
+    <button type="button" v-on:mousedown.prevent="NumberedList">1.</button>
+  </div>
+  <div class="rich-text-content" ref="` + richTextEditor + `" contenteditable="true" v-model="%s"
+    style="padding: var(--vue-rich-text-padding, 8px); min-height: var(--vue-rich-text-height, 6em)"></div>
+</div>
+`
+
+// richTextEditor is the ref of the editable element of the rich text component.
+const richTextEditor = "editor"
+
+// richTextAtoms are the elements allowed by sanitization.
+var richTextAtoms = map[atom.Atom]struct{}{
+	atom.B: {}, atom.Strong: {}, atom.I: {}, atom.Em: {}, atom.U: {},
+	atom.Ul: {}, atom.Ol: {}, atom.Li: {}, atom.P: {}, atom.Br: {}, atom.Div: {},
+}
+
+// RichText creates a rich text component which binds the sanitized html to the data field.
+// The field is bound by the parent with the model attribute, e.g. <rich-text v-model:Body="Body">,
+// which is updated by the update event of the prop on input, e.g. of an editor nested in a subcomponent.
+// The editor is restyled by the tokens of the theme, see Theme: vue-rich-text-border, vue-rich-text-toolbar,
+// vue-rich-text-padding and vue-rich-text-height.
+func RichText(field string) *Comp {
+	return Component(
+		Template(fmt.Sprintf(richTextTmpl, field)),
+		Props(field),
+		Methods(map[string]func(Context){
+			"Bold": func(context Context) {
+				formatSelection(context, "b", "")
+			},
+			"Italic": func(context Context) {
+				formatSelection(context, "i", "")
+			},
+			"BulletList": func(context Context) {
+				formatSelection(context, "ul", "li")
+			},
+			"NumberedList": func(context Context) {
+				formatSelection(context, "ol", "li")
+			},
+		}),
+	)
+}
+
+// sanitize returns the html with only allowed elements and without attributes.
+// Script and style elements are removed with their content, other elements are unwrapped.
+func sanitize(value string) string {
+	node := parseNode(value)
+	sanitizeNode(node)
+
+	buf := bytes.NewBuffer(nil)
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		err := html.Render(buf, child)
+		must(err)
+	}
+	return buf.String()
+}
+
+// sanitizeNode recursively sanitizes the children of the node.
+func sanitizeNode(node *html.Node) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		switch child.Type {
+		case html.TextNode:
+		case html.ElementNode:
+			sanitizeNode(child)
+			if _, ok := richTextAtoms[child.DataAtom]; ok {
+				child.Attr = nil
+				break
+			}
+			if child.DataAtom != atom.Script && child.DataAtom != atom.Style {
+				for _, grandchild := range children(child) {
+					child.RemoveChild(grandchild)
+					node.InsertBefore(grandchild, child)
+				}
+			}
+			node.RemoveChild(child)
+		default:
+			node.RemoveChild(child)
+		}
+		child = next
+	}
+}
+
+// isContentEditable determines if the attributes make an element editable.
+func isContentEditable(attrs map[string]string) bool {
+	value, ok := attrs[contentEditable]
+	return ok && !strings.EqualFold(value, "false")
+}
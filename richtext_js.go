@@ -0,0 +1,40 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"syscall/js"
+)
+
+// formatSelection wraps the selection within the editor of the rich text component by the element of the tag,
+// e.g. b for bold, or by the item of the element for lists, then selects the wrapped content.
+// The editor is notified of input, so the field is set to its sanitized html.
+// Selections outside of the editor and empty selections are left as is.
+func formatSelection(context Context, tag, item string) {
+	editor := context.Ref(richTextEditor)
+	selection := js.Global().Call("getSelection")
+	if editor == js.Null() || selection == js.Null() || selection.Get("rangeCount").Int() == 0 {
+		return
+	}
+	r := selection.Call("getRangeAt", 0)
+	if r.Get("collapsed").Bool() || !editor.Call("contains", r.Get("commonAncestorContainer")).Bool() {
+		return
+	}
+
+	document := js.Global().Get("document")
+	wrapper := document.Call("createElement", tag)
+	content := wrapper
+	if item != "" {
+		content = document.Call("createElement", item)
+		wrapper.Call("appendChild", content)
+	}
+	content.Call("appendChild", r.Call("extractContents"))
+	r.Call("insertNode", wrapper)
+
+	selected := document.Call("createRange")
+	selected.Call("selectNodeContents", content)
+	selection.Call("removeAllRanges")
+	selection.Call("addRange", selected)
+	editor.Call("dispatchEvent", js.Global().Get("Event").New("input", map[string]interface{}{"bubbles": true}))
+}
@@ -0,0 +1,130 @@
+//go:build js && wasm
+// +build js,wasm
+
+package router
+
+import (
+	"strings"
+	"syscall/js"
+)
+
+// listen adds the function as an event listener of the target.
+// The function receives the event.
+func listen(target js.Value, typ string, fn func(event js.Value)) {
+	cb := js.NewCallback(func(args []js.Value) {
+		fn(args[0])
+	})
+	target.Call("addEventListener", typ, cb)
+}
+
+// linkHandler returns the click handler of router links, which calls the navigation with the path of the link.
+// Callbacks are asynchronous, so the handler prevents the default action of the event in JavaScript.
+// Clicks with modifier keys or other buttons are left to the browser, e.g. to open new tabs.
+var linkHandler = js.Global().Get("Function").New("navigate", `return function(event) {
+	if (event.defaultPrevented || event.button !== 0 || event.metaKey || event.ctrlKey || event.shiftKey || event.altKey) {
+		return;
+	}
+	var link = event.target.closest("a[`+linkAttr+`]");
+	if (link === null) {
+		return;
+	}
+	event.preventDefault();
+	navigate(link.getAttribute("`+linkAttr+`"));
+}`)
+
+// listenLinks adds the click handler of router links to the target.
+// The function receives the path of the clicked link.
+func listenLinks(target js.Value, fn func(path string)) {
+	cb := js.NewCallback(func(args []js.Value) {
+		fn(args[0].String())
+	})
+	target.Call("addEventListener", "click", linkHandler.Invoke(cb))
+}
+
+// start routes the current location, then routes again on navigation.
+// Paths without a supported locale redirect to the detected locale.
+func (r *Router) start() {
+	path := r.localize(r.path())
+	if path != r.path() {
+		r.replace(path)
+	}
+	r.route(path)
+	if !r.history {
+		listen(js.Global(), "hashchange", func(js.Value) {
+			if r.pushed {
+				r.pushed = false
+				return
+			}
+			r.restore()
+		})
+		return
+	}
+	listen(js.Global(), "popstate", func(js.Value) {
+		r.restore()
+	})
+	listenLinks(js.Global().Get("document"), r.Push)
+}
+
+// Push navigates to the path, e.g. router.Push("/users/42").
+// Once the hooks proceed, the path is pushed to the history in history mode, otherwise it is set as the location hash.
+func (r *Router) Push(path string) {
+	path = r.localize(path)
+	r.navigate(path, false, func() {
+		if r.history {
+			js.Global().Get("history").Call("pushState", nil, "", r.base+path)
+			return
+		}
+		if r.path() != path {
+			r.pushed = true
+			js.Global().Get("location").Set("hash", path)
+		}
+	})
+}
+
+// restore navigates to the location changed by the browser, e.g. by the back button.
+// The url of the current route is restored until the hooks proceed, so canceled navigations keep the url.
+// Locations without a supported locale are replaced by the localized location.
+func (r *Router) restore() {
+	path := r.localize(r.path())
+	r.replace(r.current)
+	r.navigate(path, true, func() {
+		r.replace(path)
+	})
+}
+
+// replace replaces the url of the current history entry by the path without navigating.
+// Replacing the location hash by the history api does not emit the hashchange event.
+func (r *Router) replace(path string) {
+	url := r.base + path
+	if !r.history {
+		url = "#" + path
+	}
+	js.Global().Get("history").Call("replaceState", nil, "", url)
+}
+
+// path returns the path and query of the current location without the base path,
+// or of the location hash in hash mode, which is / without a path.
+func (r *Router) path() string {
+	location := js.Global().Get("location")
+	var path string
+	if r.history {
+		path = strings.TrimPrefix(location.Get("pathname").String(), r.base) + location.Get("search").String()
+	} else {
+		path = strings.TrimPrefix(location.Get("hash").String(), "#")
+	}
+	if path == "" || strings.HasPrefix(path, "?") {
+		return "/" + path
+	}
+	return path
+}
+
+// scrollPosition returns the scroll position of the page.
+func scrollPosition() Position {
+	window := js.Global()
+	return Position{X: window.Get("scrollX").Float(), Y: window.Get("scrollY").Float()}
+}
+
+// scrollTo scrolls the page to the position.
+func scrollTo(position Position) {
+	js.Global().Call("scrollTo", position.X, position.Y)
+}
@@ -0,0 +1,85 @@
+package router
+
+import (
+	"strings"
+)
+
+// Locales is the option of locale-prefixed paths, e.g. /en/about and /de/about, which both route /about.
+// The locale of the path is set as the locale of translations on navigation, which is provided by Route.Locale.
+// Paths without a supported locale redirect to the detected locale, which is the locale of translations,
+// e.g. the locale of the user, otherwise its language, otherwise the first locale, which is the default.
+// Links and pushed paths without a locale are prefixed by the locale of the current route,
+// e.g. <router-link to="/about"> links to /de/about while the locale is de.
+func Locales(locales ...string) Option {
+	return func(r *Router) {
+		r.locales = locales
+	}
+}
+
+// SetLocale navigates to the path of the current route in the locale, e.g. by a language switcher.
+func (r *Router) SetLocale(locale string) {
+	_, path := r.splitLocale(r.current)
+	r.Push("/" + locale + path)
+}
+
+// splitLocale splits the supported locale from the path, which is / without a rest.
+// Returns an empty locale for paths without a supported locale.
+func (r *Router) splitLocale(path string) (string, string) {
+	trimmed := strings.TrimPrefix(path, "/")
+	i := strings.IndexAny(trimmed, "/?")
+	if i < 0 {
+		i = len(trimmed)
+	}
+	for _, locale := range r.locales {
+		if trimmed[:i] != locale {
+			continue
+		}
+		rest := trimmed[i:]
+		if !strings.HasPrefix(rest, "/") {
+			rest = "/" + rest
+		}
+		return locale, rest
+	}
+	return "", path
+}
+
+// localize prefixes the path without a supported locale by the locale of the current route,
+// otherwise by the detected locale. Paths are kept without locales.
+func (r *Router) localize(path string) string {
+	if len(r.locales) == 0 {
+		return path
+	}
+	if locale, _ := r.splitLocale(path); locale != "" {
+		return path
+	}
+	locale := r.location.Locale
+	if locale == "" {
+		locale = r.detectLocale()
+	}
+	return "/" + locale + path
+}
+
+// detectLocale returns the supported locale of translations, otherwise of its language,
+// otherwise the default locale.
+func (r *Router) detectLocale() string {
+	current := r.in.Locale()
+	for _, locale := range r.locales {
+		if strings.EqualFold(locale, current) {
+			return locale
+		}
+	}
+	for _, locale := range r.locales {
+		if strings.EqualFold(locale, language(current)) {
+			return locale
+		}
+	}
+	return r.locales[0]
+}
+
+// language returns the language of regional locales, e.g. fr for fr-CA.
+func language(locale string) string {
+	if i := strings.IndexAny(locale, "-_"); i > 0 {
+		return locale[:i]
+	}
+	return locale
+}
@@ -0,0 +1,55 @@
+package router
+
+import (
+	"net/url"
+	"strings"
+)
+
+// catchAll is the path of the route which matches any path.
+const catchAll = "*"
+
+// match matches the path to the path of the route, e.g. /users/42 to /users/:id.
+// The catch all segment matches the rest of the path, e.g. /docs/* matches /docs/a/b.
+// Returns the params of the path by name, which are unescaped.
+func match(pattern, path string) (map[string]string, bool) {
+	params := make(map[string]string)
+	patterns := strings.Split(strings.Trim(pattern, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, p := range patterns {
+		if p == catchAll {
+			return params, true
+		}
+		if i >= len(segments) {
+			return nil, false
+		}
+		if !strings.HasPrefix(p, ":") {
+			if p != segments[i] {
+				return nil, false
+			}
+			continue
+		}
+		value, err := url.PathUnescape(segments[i])
+		if err != nil || value == "" {
+			return nil, false
+		}
+		params[p[1:]] = value
+	}
+	if len(patterns) != len(segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+// splitQuery splits the path from its query, which is parsed into the first value by name.
+func splitQuery(path string) (string, map[string]string) {
+	query := make(map[string]string)
+	i := strings.Index(path, "?")
+	if i < 0 {
+		return path, query
+	}
+	values, _ := url.ParseQuery(path[i+1:])
+	for name := range values {
+		query[name] = values.Get(name)
+	}
+	return path[:i], query
+}
@@ -0,0 +1,86 @@
+package router
+
+import (
+	"github.com/norunners/vue"
+)
+
+// Position is the scroll position of the page.
+type Position struct {
+	X, Y float64
+}
+
+// ScrollBehavior is the option of the scroll position after navigation.
+// The function receives the saved position of the route when navigating back or forward, otherwise nil,
+// and returns the position to scroll to, or nil to keep the scroll position.
+// By default, saved positions are restored, otherwise the page is scrolled to the top.
+func ScrollBehavior(scroll func(to, from *Location, saved *Position) *Position) Option {
+	return func(r *Router) {
+		r.scroll = scroll
+	}
+}
+
+// BeforeEach is the option of a hook which is called before each navigation,
+// e.g. to start a leave transition of the outgoing component or to guard routes.
+// The navigation proceeds once next is called, which may be called later, e.g. after the transition ends.
+// Navigation is canceled while next is not called, which keeps the url of the current route.
+// The url changes once all hooks proceed.
+// Hooks are called in order.
+func BeforeEach(hook func(to, from *Location, next func())) Option {
+	return func(r *Router) {
+		r.beforeEach = append(r.beforeEach, hook)
+	}
+}
+
+// AfterEach is the option of a hook which is called after each navigation renders the incoming component,
+// e.g. to start its enter transition.
+func AfterEach(hook func(to, from *Location)) Option {
+	return func(r *Router) {
+		r.afterEach = append(r.afterEach, hook)
+	}
+}
+
+// navigate calls the hooks before navigation, commits the url, routes the path then renders.
+// The route is emitted to the analytics of root components.
+// The scroll position of the previous route is saved, which is restored by navigating back or forward.
+func (r *Router) navigate(path string, restore bool, commit func()) {
+	from := *r.location
+	_, to := r.resolve(path)
+	r.before(0, to, &from, func() {
+		commit()
+		r.scrolls[from.Path] = scrollPosition()
+		r.route(path)
+		r.in.ForceUpdate()
+		r.in.Emit(vue.AnalyticsRoute, to.Path)
+		r.in.NextTick(func() {
+			var saved *Position
+			if position, ok := r.scrolls[to.Path]; ok && restore {
+				saved = &position
+			}
+			if position := r.scroll(to, &from, saved); position != nil {
+				scrollTo(*position)
+			}
+			for _, hook := range r.afterEach {
+				hook(to, &from)
+			}
+		})
+	})
+}
+
+// before calls the hook by index before navigation, then the next hook by next, then proceeds.
+func (r *Router) before(i int, to, from *Location, proceed func()) {
+	if i == len(r.beforeEach) {
+		proceed()
+		return
+	}
+	r.beforeEach[i](to, from, func() {
+		r.before(i+1, to, from, proceed)
+	})
+}
+
+// scrollBehavior restores the saved position, otherwise scrolls to the top.
+func scrollBehavior(_, _ *Location, saved *Position) *Position {
+	if saved != nil {
+		return saved
+	}
+	return &Position{}
+}
@@ -0,0 +1,311 @@
+// Package router routes the location to components, e.g. for single page applications.
+// The router is installed as a plugin, which registers the router-view and router-link components:
+//
+//	r := router.New([]router.Route{
+//		{Path: "/", Component: home},
+//		{Path: "/about", Component: about},
+//		{Path: "*", Component: notFound},
+//	})
+//	vue.Use(r)
+//
+// The router-view element renders the component of the current route,
+// and the router-link element navigates to its path, e.g. <router-link to="/about">About</router-link>.
+// Links to the current route have the active class.
+//
+// Paths of routes declare params by segments, e.g. /users/:id, which are bound to the props of the component by name.
+// Int props are parsed from the params. The current location, including the query,
+// is provided to components which inject Route, e.g. {{ Route.Params.id }} or {{ Route.Query.page }}.
+//
+// Routes nest child routes, which are rendered by the router-view of the component of the parent route.
+// Paths of child routes are relative to their parent, where the empty path matches the path of the parent.
+// Named views render the components of a route by the name of the router-view, e.g. <router-view name="sidebar">,
+// while the router-view without a name renders the component of the route.
+//
+// The locales option prefixes paths by the locale of translations, e.g. /de/about, which routes like /about,
+// and redirects paths without a locale to the locale of the user.
+//
+// Routes are in the location hash by default, e.g. /#/about.
+// The history option routes clean urls by the history api instead, e.g. /about,
+// which requires the server to respond with the application for every route.
+package router
+
+import (
+	"fmt"
+	"github.com/norunners/vue"
+	"html"
+	"strings"
+)
+
+// Elements of the components registered by the router.
+const (
+	viewElement  = "router-view"
+	linkElement  = "router-link"
+	emptyElement = "router-empty"
+)
+
+// linkAttr is the attribute of router links to the path, which are intercepted in history mode.
+const linkAttr = "data-router-link"
+
+// Classes of router links.
+const (
+	defaultActiveClass = "router-link-active"
+	exactActiveClass   = "router-link-exact-active"
+)
+
+// routeKey is the key of the provided location of the current route.
+const routeKey = "Route"
+
+// Route maps the path to the component and the components of named views.
+type Route struct {
+	Path       string
+	Component  *vue.Comp
+	Components map[string]*vue.Comp
+	Children   []Route
+}
+
+// record is a route of the flattened route table.
+// Records of children precede their parent, so the empty path of a child matches first.
+type record struct {
+	id     int
+	path   string
+	route  Route
+	parent *record
+	depth  int
+}
+
+// Option uses the option pattern for routers.
+type Option func(*Router)
+
+// Router routes the location to components.
+type Router struct {
+	records     []*record
+	depth       int
+	activeClass string
+	history     bool
+	base        string
+	location    *Location
+	views       []*view
+	in          *vue.Installer
+
+	locales    []string
+	pushed     bool
+	current    string
+	scrolls    map[string]Position
+	scroll     func(to, from *Location, saved *Position) *Position
+	beforeEach []func(to, from *Location, next func())
+	afterEach  []func(to, from *Location)
+}
+
+// Location is the location of the current route.
+// The locale is the locale of locale-prefixed paths, which is empty without the locales option.
+type Location struct {
+	Path   string
+	Params map[string]string
+	Query  map[string]string
+	Locale string
+}
+
+// view is the data of the router-view components of a depth.
+type view struct {
+	Views  map[string]string
+	Params map[string]string
+}
+
+// New creates a new router of the routes, which match in order.
+// The catch all path, *, matches any path, e.g. to render a not found page.
+func New(routes []Route, options ...Option) *Router {
+	r := &Router{activeClass: defaultActiveClass, location: &Location{}, scrolls: make(map[string]Position), scroll: scrollBehavior}
+	r.flatten(routes, nil)
+	// The router-view of the deepest components renders empty.
+	for depth := 0; depth <= r.depth+1; depth++ {
+		r.views = append(r.views, &view{})
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// flatten recursively flattens the routes and their children into records.
+func (r *Router) flatten(routes []Route, parent *record) {
+	for _, route := range routes {
+		rec := &record{route: route, parent: parent, path: route.Path}
+		if parent != nil {
+			rec.depth = parent.depth + 1
+			if !strings.HasPrefix(route.Path, "/") {
+				rec.path = strings.TrimSuffix(parent.path, "/") + "/" + route.Path
+			}
+		}
+		if rec.depth > r.depth {
+			r.depth = rec.depth
+		}
+		r.flatten(route.Children, rec)
+		rec.id = len(r.records)
+		r.records = append(r.records, rec)
+	}
+}
+
+// ActiveClass is the option of the class of links to the current route and its children,
+// which is router-link-active by default.
+// Links to exactly the current route also have the router-link-exact-active class.
+func ActiveClass(class string) Option {
+	return func(r *Router) {
+		r.activeClass = class
+	}
+}
+
+// History is the option to route clean urls by the history api instead of the location hash.
+// The base path is the prefix of all routes, e.g. /app for /app/about, which is empty to route from the root.
+func History(base string) Option {
+	return func(r *Router) {
+		r.history = true
+		r.base = strings.TrimSuffix(base, "/")
+	}
+}
+
+// Install registers the components of the routes, router-view and router-link,
+// then routes the current location and routes again on navigation.
+// On the server, Install routes the root path and Push routes other paths, e.g. to render the routes to html.
+// The location of the current route is provided to all components.
+// The components of routes render the router-view of the next depth.
+func (r *Router) Install(in *vue.Installer) {
+	r.in = in
+	in.Option(vue.Provide(routeKey, r.location))
+	views := make([]*vue.Comp, len(r.views))
+	for depth, v := range r.views {
+		views[depth] = vue.Component(
+			vue.Render(r.render(depth)),
+			vue.Data(v),
+			vue.Props("name"),
+		)
+	}
+	for _, rec := range r.records {
+		for name, comp := range rec.components() {
+			vue.Sub(viewElement, views[rec.depth+1])(comp)
+			in.Component(routeElement(rec.id, name), comp)
+		}
+	}
+	in.Component(emptyElement, vue.Functional(func(map[string]interface{}) string {
+		return ""
+	}))
+	in.Component(viewElement, views[0])
+	in.Component(linkElement, vue.Functional(r.link, "to"))
+	r.start()
+}
+
+// Paths returns the paths of the routes and their children, e.g. to prerender the routes.
+// Paths of child routes are joined to the paths of their parents.
+func (r *Router) Paths() []string {
+	paths := make([]string, len(r.records))
+	for i, rec := range r.records {
+		paths[i] = rec.path
+	}
+	return paths
+}
+
+// Current returns the path of the current route.
+func (r *Router) Current() string {
+	return r.location.Path
+}
+
+// Route returns the location of the current route, e.g. to read params from methods.
+func (r *Router) Route() *Location {
+	return r.location
+}
+
+// resolve resolves the path to the first matching route and the location.
+// The route is nil without a matching route. The supported locale of the path is split from the path.
+func (r *Router) resolve(path string) (*record, *Location) {
+	locale, path := r.splitLocale(path)
+	path, query := splitQuery(path)
+	location := &Location{Path: path, Params: map[string]string{}, Query: query, Locale: locale}
+	for _, rec := range r.records {
+		if params, ok := match(rec.path, path); ok {
+			location.Params = params
+			return rec, location
+		}
+	}
+	return nil, location
+}
+
+// route routes the path to the components of the first matching route and its ancestors by depth.
+// The views are empty without a matching route. The locale of the path is set as the locale of translations.
+func (r *Router) route(path string) {
+	rec, location := r.resolve(path)
+	*r.location = *location
+	r.current = path
+	if location.Locale != "" && location.Locale != r.in.Locale() {
+		r.in.SetLocale(location.Locale)
+	}
+	for _, v := range r.views {
+		*v = view{Views: map[string]string{}}
+	}
+	for ; rec != nil; rec = rec.parent {
+		v := r.views[rec.depth]
+		v.Params = location.Params
+		for name := range rec.components() {
+			v.Views[name] = routeElement(rec.id, name)
+		}
+	}
+}
+
+// render creates the render function of the router-view of the depth,
+// which renders the component of the view by name with the params as attributes, which bind props.
+func (r *Router) render(depth int) func(vue.Context) *vue.Node {
+	return func(context vue.Context) *vue.Node {
+		v := r.views[depth]
+		name, _ := context.Get("name").(string)
+		element, ok := v.Views[name]
+		if !ok {
+			element = emptyElement
+		}
+		attrs := make(vue.Attrs, len(v.Params))
+		for param, value := range v.Params {
+			attrs[param] = value
+		}
+		return vue.H(element, attrs)
+	}
+}
+
+// components returns the components of the route by view name, where the component of the route is unnamed.
+func (rec *record) components() map[string]*vue.Comp {
+	comps := make(map[string]*vue.Comp, len(rec.route.Components)+1)
+	for name, comp := range rec.route.Components {
+		comps[name] = comp
+	}
+	if rec.route.Component != nil {
+		comps[""] = rec.route.Component
+	}
+	return comps
+}
+
+// link renders the router link to the path of the to prop with the active classes.
+// Paths without a locale link to the locale of the current route.
+// The children of the link fill the slot.
+func (r *Router) link(props map[string]interface{}) string {
+	to := r.localize(fmt.Sprint(props["to"]))
+	_, path := r.splitLocale(to)
+	path, _ = splitQuery(path)
+	current := r.location.Path
+	var classes []string
+	if current == path || strings.HasPrefix(current, strings.TrimSuffix(path, "/")+"/") {
+		classes = append(classes, r.activeClass)
+	}
+	if current == path {
+		classes = append(classes, exactActiveClass)
+	}
+	href := "#" + to
+	if r.history {
+		href = r.base + to
+	}
+	return fmt.Sprintf(`<a href="%s" class="%s" %s="%s"><slot></slot></a>`,
+		html.EscapeString(href), html.EscapeString(strings.Join(classes, " ")), linkAttr, html.EscapeString(to))
+}
+
+// routeElement returns the element of the component of the route by id and view name.
+func routeElement(id int, name string) string {
+	if name == "" {
+		return fmt.Sprintf("router-route-%d", id)
+	}
+	return fmt.Sprintf("router-route-%d-%s", id, name)
+}
@@ -0,0 +1,25 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package router
+
+// On the server, the router has no location, so routes are rendered by path, e.g. to prerender each route.
+// Navigation hooks and scrolling are left to the browser.
+
+// start routes the root path.
+func (r *Router) start() {
+	r.route(r.localize("/"))
+}
+
+// Push routes the path on the server, which the next render renders, e.g. router.Push("/about").
+func (r *Router) Push(path string) {
+	r.route(r.localize(path))
+}
+
+// scrollPosition is the top of the page on the server.
+func scrollPosition() Position {
+	return Position{}
+}
+
+// scrollTo is a no-op on the server.
+func scrollTo(Position) {}
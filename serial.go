@@ -0,0 +1,36 @@
+package vue
+
+// serialConnectedField is the data field of the serial connection state.
+const serialConnectedField = "SerialConnected"
+
+// serial is the experimental serial port binding of a component.
+type serial struct {
+	field        string
+	baudRate     int
+	onConnect    func(Context)
+	onDisconnect func(Context)
+
+	vm        *ViewModel
+	connected bool
+	serialStream
+}
+
+// Serial is the experimental serial port option for root components.
+// Lines read from the port are streamed into the data field, e.g. for hardware dashboards.
+// The connection state is mapped to the SerialConnected data field unless data has the same field.
+// The transition functions are called when the port connects and disconnects, either may be nil.
+func Serial(field string, baudRate int, onConnect, onDisconnect func(Context)) Option {
+	return func(comp *Comp) {
+		comp.serial = &serial{field: field, baudRate: baudRate, onConnect: onConnect, onDisconnect: onDisconnect}
+	}
+}
+
+// serialData maps the serial connection state to data.
+func (vm *ViewModel) serialData() {
+	if vm.comp.serial == nil {
+		return
+	}
+	if _, ok := vm.data[serialConnectedField]; !ok {
+		vm.data[serialConnectedField] = vm.comp.serial.connected
+	}
+}
@@ -0,0 +1,111 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"fmt"
+	"strings"
+	"syscall/js"
+)
+
+// serialStream is the port of the browser and its reader.
+type serialStream struct {
+	port   js.Value
+	reader js.Value
+}
+
+// serialPort is the serial port shared by components.
+var serialPort *serial
+
+// ConnectSerial requests a serial port from the user then streams lines from the port until disconnected.
+// The request must be made from a user input, e.g. a click method.
+// ConnectSerial blocks until the port is opened, so it must be called from a goroutine.
+func ConnectSerial() error {
+	if serialPort == nil || serialPort.vm == nil {
+		return fmt.Errorf("serial option is not set")
+	}
+	if serialPort.connected {
+		return nil
+	}
+	api := js.Global().Get("navigator").Get("serial")
+	if api == js.Undefined() {
+		return fmt.Errorf("serial is not supported")
+	}
+
+	port, err := await(api.Call("requestPort"))
+	if err != nil {
+		return err
+	}
+	options := js.Global().Get("Object").New()
+	options.Set("baudRate", serialPort.baudRate)
+	if _, err := await(port.Call("open", options)); err != nil {
+		return err
+	}
+
+	decoder := js.Global().Get("TextDecoderStream").New()
+	serialPort.port = port
+	serialPort.reader = port.Get("readable").Call("pipeThrough", decoder).Call("getReader")
+	serialPort.transition(true)
+	go serialPort.read()
+	return nil
+}
+
+// DisconnectSerial cancels reading which closes the serial port.
+func DisconnectSerial() {
+	if serialPort == nil || !serialPort.connected {
+		return
+	}
+	serialPort.reader.Call("cancel")
+}
+
+// watchSerial binds the serial port to the root view model.
+func (vm *ViewModel) watchSerial() {
+	if vm.comp.serial == nil || vm.comp.isSub {
+		return
+	}
+	vm.comp.serial.vm = vm
+	serialPort = vm.comp.serial
+}
+
+// read streams lines from the serial port into the data field until the port is done.
+func (serial *serial) read() {
+	defer serial.close()
+
+	var buf string
+	for {
+		result, err := await(serial.reader.Call("read"))
+		if err != nil || result.Get("done").Bool() {
+			return
+		}
+		buf += result.Get("value").String()
+		lines := strings.Split(buf, "\n")
+		buf = lines[len(lines)-1]
+		if len(lines) > 1 {
+			serial.vm.Set(serial.field, strings.TrimSpace(lines[len(lines)-2]))
+			serial.vm.render()
+		}
+	}
+}
+
+// close closes the serial port after reading.
+func (serial *serial) close() {
+	serial.reader.Call("releaseLock")
+	serial.port.Call("close")
+	serial.transition(false)
+}
+
+// transition transitions the connection state then renders.
+func (serial *serial) transition(connected bool) {
+	vm := serial.vm
+	defer vm.report()
+	serial.connected = connected
+	vm.breadcrumb("serial: %t", connected)
+	if connected && serial.onConnect != nil {
+		serial.onConnect(vm)
+	}
+	if !connected && serial.onDisconnect != nil {
+		serial.onDisconnect(vm)
+	}
+	vm.render()
+}
@@ -0,0 +1,323 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"errors"
+	"golang.org/x/net/html"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Outside of the browser, components render on the server, e.g. by RenderToString, or headlessly.
+// View models have no dom, so the hooks of the browser are no-ops.
+// Browser apis report errors or keep their defaults, e.g. the page is visible and not fullscreen.
+
+// serverLocale is the locale of the user on the server.
+const serverLocale = "en-US"
+
+// errBrowser is the error of browser apis on the server.
+var errBrowser = errors.New("browser api is unavailable on the server")
+
+// element is the element of the el option, which is always nil on the server.
+type element interface{}
+
+// Value is the javascript value of refs and events, which is empty on the server.
+// The methods of the value return zero values, so methods of components compile for both the browser and the server.
+type Value struct{}
+
+// Get returns the empty value.
+func (Value) Get(string) Value {
+	return Value{}
+}
+
+// Set is a no-op on the server.
+func (Value) Set(string, interface{}) {}
+
+// Index returns the empty value.
+func (Value) Index(int) Value {
+	return Value{}
+}
+
+// SetIndex is a no-op on the server.
+func (Value) SetIndex(int, interface{}) {}
+
+// Call returns the empty value.
+func (Value) Call(string, ...interface{}) Value {
+	return Value{}
+}
+
+// Invoke returns the empty value.
+func (Value) Invoke(...interface{}) Value {
+	return Value{}
+}
+
+// New returns the empty value.
+func (Value) New(...interface{}) Value {
+	return Value{}
+}
+
+// InstanceOf is false on the server.
+func (Value) InstanceOf(Value) bool {
+	return false
+}
+
+// Length is zero on the server.
+func (Value) Length() int {
+	return 0
+}
+
+// Bool is false on the server.
+func (Value) Bool() bool {
+	return false
+}
+
+// Int is zero on the server.
+func (Value) Int() int {
+	return 0
+}
+
+// Float is zero on the server.
+func (Value) Float() float64 {
+	return 0
+}
+
+// String is empty on the server.
+func (Value) String() string {
+	return ""
+}
+
+// Event is the dom event which triggered a method.
+// Outside of the browser, events are dispatched headlessly with their payload by Dispatch.
+type Event struct {
+	typ    string
+	init   EventInit
+	target *html.Node
+}
+
+// Type returns the type of the event.
+func (e *Event) Type() string {
+	return e.typ
+}
+
+// Value returns the value of the target element.
+func (e *Event) Value() string {
+	return e.init.Value
+}
+
+// Checked returns the checked state of the target element.
+func (e *Event) Checked() bool {
+	return e.init.Checked
+}
+
+// Key returns the key of keyboard events, which is empty for other types of events.
+func (e *Event) Key() string {
+	return e.init.Key
+}
+
+// ClientX returns the horizontal coordinate of mouse events, which is zero for other types of events.
+func (e *Event) ClientX() float64 {
+	return e.init.ClientX
+}
+
+// ClientY returns the vertical coordinate of mouse events, which is zero for other types of events.
+func (e *Event) ClientY() float64 {
+	return e.init.ClientY
+}
+
+// DataTransfer returns the empty value on the server.
+func (e *Event) DataTransfer() Value {
+	return Value{}
+}
+
+// Files returns the empty value on the server.
+func (e *Event) Files() Value {
+	return Value{}
+}
+
+// Target returns the element of the rendered tree the event was dispatched to.
+func (e *Event) Target() *html.Node {
+	return e.target
+}
+
+// PreventDefault is a no-op on the server.
+func (e *Event) PreventDefault() {}
+
+// StopPropagation is a no-op on the server.
+func (e *Event) StopPropagation() {}
+
+// Underlying returns the empty value on the server.
+func (e *Event) Underlying() Value {
+	return Value{}
+}
+
+// Ref returns the empty value on the server, where no elements are rendered.
+func (vm *ViewModel) Ref(name string) Value {
+	return Value{}
+}
+
+// serialStream is the port of the browser, which is empty on the server.
+type serialStream struct{}
+
+// querySelector returns no element on the server.
+func querySelector(string) element {
+	return nil
+}
+
+// addEventListener is a no-op on the server.
+func (vm *ViewModel) addEventListener(attr, typ string) {}
+
+// formatSelection is a no-op on the server, where nothing is selected.
+func formatSelection(Context, string, string) {}
+
+// loadCatalog loads no catalogs on the server, where messages of the fallback locale are translated.
+func loadCatalog(locale, url string) {}
+
+// requestAnimationFrame is a no-op on the server.
+func requestAnimationFrame(func()) {}
+
+// warn logs the error of the component.
+func (comp *Comp) warn(err error) {
+	log.Printf("%s: %v", comp.displayName(), err)
+}
+
+// loadTemplate loads no compiled templates on the server.
+func loadTemplate(string) (*html.Node, bool) {
+	return nil, false
+}
+
+// storeTemplate is a no-op on the server.
+func storeTemplate(string, *html.Node) {}
+
+// locale returns the locale of the user on the server.
+func locale() string {
+	return serverLocale
+}
+
+// number formats the number with the group separators of en-US on the server, like in the browser.
+// The optional argument is the precision.
+func number(value interface{}, args ...string) interface{} {
+	precision := -1
+	if len(args) > 0 {
+		p, err := strconv.Atoi(args[0])
+		must(err)
+		precision = p
+	}
+	return group(strconv.FormatFloat(round(toFloat(value), precision), 'f', precision, 64))
+}
+
+// currencySymbols are the symbols of currencies in en-US by code, with their precision.
+// Other currencies are prefixed by their code with a precision of 2.
+var currencySymbols = map[string]struct {
+	symbol    string
+	precision int
+}{
+	"USD": {"$", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"JPY": {"¥", 0},
+	"INR": {"₹", 2},
+	"CAD": {"CA$", 2},
+	"AUD": {"A$", 2},
+	"CNY": {"CN¥", 2},
+	"BRL": {"R$", 2},
+}
+
+// formatLocale formats the number in en-US on the server, like Intl.NumberFormat in the browser,
+// e.g. $1,234.50 for currencies and 1,234.5 for numbers.
+func formatLocale(value float64, format, currency string) string {
+	if format != formatCurrency {
+		return group(strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	symbol, precision := currency+"\u00a0", 2
+	if c, ok := currencySymbols[currency]; ok {
+		symbol, precision = c.symbol, c.precision
+	}
+	text := group(strconv.FormatFloat(round(math.Abs(value), precision), 'f', precision, 64))
+	if value < 0 && strings.Trim(text, "0.,") != "" {
+		return "-" + symbol + text
+	}
+	return symbol + text
+}
+
+// round rounds half away from zero to the precision like Intl.NumberFormat, unless the precision is negative.
+// strconv rounds half to even, e.g. 1234.5 to 1234 instead of 1235.
+func round(value float64, precision int) float64 {
+	if precision < 0 {
+		return value
+	}
+	scale := math.Pow(10, float64(precision))
+	return math.Round(value*scale) / scale
+}
+
+// group inserts the group separators of en-US into the integer part of the formatted number.
+func group(text string) string {
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	integer, fraction := text, ""
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		integer, fraction = text[:i], text[i:]
+	}
+	var b strings.Builder
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return sign + b.String() + fraction
+}
+
+// isFullscreen is false on the server.
+func isFullscreen() bool {
+	return false
+}
+
+// isVisible is true on the server.
+func isVisible() bool {
+	return true
+}
+
+// wakeLocked is false on the server.
+func wakeLocked() bool {
+	return false
+}
+
+// RequestFullscreen is a no-op on the server.
+func RequestFullscreen(selector string) {}
+
+// ExitFullscreen is a no-op on the server.
+func ExitFullscreen() {}
+
+// ConnectSerial fails on the server.
+func ConnectSerial() error {
+	return errBrowser
+}
+
+// DisconnectSerial is a no-op on the server.
+func DisconnectSerial() {}
+
+// StartRecognition is a no-op on the server.
+func StartRecognition() {}
+
+// StopRecognition is a no-op on the server.
+func StopRecognition() {}
+
+// Speak is a no-op on the server.
+func Speak(text string) {}
+
+// CancelSpeech is a no-op on the server.
+func CancelSpeech() {}
+
+// RequestWakeLock fails on the server.
+func RequestWakeLock() error {
+	return errBrowser
+}
+
+// ReleaseWakeLock is a no-op on the server.
+func ReleaseWakeLock() {}
@@ -0,0 +1,402 @@
+// Package sfc converts Vue single-file components into Go components, e.g. to migrate from Vue.js.
+// The template is converted from the shorthand syntax, e.g. @click and :title, into vue attributes.
+// Props, data, methods and computed are declared from the script, where data is typed by its literals.
+// The bodies of methods and computed are not converted but left as comments to port by hand.
+// The style is declared as a constant to include in the page.
+package sfc
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"golang.org/x/net/html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Component is a single-file component split into its blocks.
+type Component struct {
+	Name     string
+	Template string
+	Script   string
+	Style    string
+}
+
+// Parse splits the single-file component into its blocks.
+// The name is the element name of the component, e.g. todo-item.
+func Parse(name string, src []byte) (*Component, error) {
+	comp := &Component{Name: name}
+	for _, tag := range []string{"template", "script", "style"} {
+		text, err := extract(string(src), tag)
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case "template":
+			comp.Template = strings.TrimSpace(text)
+		case "script":
+			comp.Script = text
+		case "style":
+			comp.Style = strings.TrimSpace(text)
+		}
+	}
+	if comp.Template == "" {
+		return nil, fmt.Errorf("missing template: %s", name)
+	}
+	return comp, nil
+}
+
+// extract returns the content of the outermost block of the tag, which may contain nested tags of the same name.
+func extract(src, tag string) (string, error) {
+	start := regexp.MustCompile(`<` + tag + `(?:\s[^>]*)?>`).FindStringIndex(src)
+	if start == nil {
+		return "", nil
+	}
+	end := strings.LastIndex(src, "</"+tag+">")
+	if end < start[1] {
+		return "", fmt.Errorf("unterminated %s", tag)
+	}
+	return src[start[1]:end], nil
+}
+
+// Convert converts the single-file component into the Go source of the package.
+// The name is the element name of the component, e.g. todo-item for a constructor named TodoItem.
+func Convert(pkg, name string, src []byte) ([]byte, error) {
+	comp, err := Parse(name, src)
+	if err != nil {
+		return nil, err
+	}
+	return comp.Generate(pkg)
+}
+
+// Generate generates the Go source of the component for the package.
+func (comp *Component) Generate(pkg string) ([]byte, error) {
+	tmpl, err := convertTemplate(comp.Template)
+	if err != nil {
+		return nil, err
+	}
+	object := exportObject(comp.Script)
+	props := members(value(object, "props"))
+	data := members(returned(value(object, "data")))
+	methods := members(value(object, "methods"))
+	computed := members(value(object, "computed"))
+
+	ident := identifier(comp.Name)
+	typ := ident + "Data"
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by vue2go from %s.vue.\n\npackage %s\n\n", comp.Name, pkg)
+	fmt.Fprintf(&b, "import \"github.com/norunners/vue\"\n\n")
+	fmt.Fprintf(&b, "const %sTemplate = %s\n\n", unexported(ident), quote(tmpl))
+	if comp.Style != "" {
+		fmt.Fprintf(&b, "// %sStyle is the style of the component to include in the page.\n", ident)
+		fmt.Fprintf(&b, "const %sStyle = %s\n\n", ident, quote(comp.Style))
+	}
+
+	fmt.Fprintf(&b, "// %s is the data of the %s component.\ntype %s struct {\n", typ, comp.Name, typ)
+	for _, m := range data {
+		goType, _ := literal(m.value)
+		fmt.Fprintf(&b, "%s %s `vue:%q`\n", identifier(m.name), goType, m.name)
+	}
+	fmt.Fprintf(&b, "}\n\n")
+
+	// Computed functions are prefixed by the component, so components of the same package do not collide.
+	for _, m := range computed {
+		fn := unexported(ident) + identifier(m.name)
+		fmt.Fprintf(&b, "// %s is the %s computed of the %s component.\nfunc %s(context vue.Context) interface{} {\n", fn, m.name, comp.Name, fn)
+		fmt.Fprintf(&b, "%s\nreturn nil\n}\n\n", comment(m.value))
+	}
+
+	fmt.Fprintf(&b, "// %s creates the %s component.\nfunc %s() *vue.Comp {\n", ident, comp.Name, ident)
+	fmt.Fprintf(&b, "data := &%s{\n", typ)
+	for _, m := range data {
+		if _, val := literal(m.value); val != "" {
+			fmt.Fprintf(&b, "%s: %s,\n", identifier(m.name), val)
+		}
+	}
+	fmt.Fprintf(&b, "}\n")
+	fmt.Fprintf(&b, "return vue.Component(\nvue.Template(%sTemplate),\nvue.Data(data),\n", unexported(ident))
+	if len(props) > 0 {
+		names := make([]string, len(props))
+		for i, m := range props {
+			names[i] = strconv.Quote(m.name)
+		}
+		fmt.Fprintf(&b, "vue.Props(%s),\n", strings.Join(names, ", "))
+	}
+	if len(methods) > 0 {
+		fmt.Fprintf(&b, "vue.Methods(map[string]func(vue.Context){\n")
+		for _, m := range methods {
+			fmt.Fprintf(&b, "%q: func(context vue.Context) {\n%s\n},\n", m.name, comment(m.value))
+		}
+		fmt.Fprintf(&b, "}),\n")
+	}
+	if len(computed) > 0 {
+		fmt.Fprintf(&b, "vue.NamedComputed(map[string]func(vue.Context) interface{}{\n")
+		for _, m := range computed {
+			fmt.Fprintf(&b, "%q: %s,\n", m.name, unexported(ident)+identifier(m.name))
+		}
+		fmt.Fprintf(&b, "}),\n")
+	}
+	fmt.Fprintf(&b, ")\n}\n")
+	return format.Source(b.Bytes())
+}
+
+// shorthands are the prefixes of shorthand attributes by their vue attributes.
+var shorthands = map[string]string{"@": "v-on:", ":": "v-bind:", "#": "v-slot:"}
+
+// convertTemplate converts the shorthand attributes of the template into vue attributes.
+// Everything else is kept as written.
+func convertTemplate(tmpl string) (string, error) {
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(tmpl))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if err := z.Err(); err.Error() != "EOF" {
+				return "", err
+			}
+			return b.String(), nil
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := z.Token()
+			if !shorthand(token.Attr) {
+				b.Write(z.Raw())
+				continue
+			}
+			for i, attr := range token.Attr {
+				if prefix, ok := shorthandPrefix(attr.Key); ok {
+					token.Attr[i].Key = prefix + attr.Key[1:]
+				}
+			}
+			b.WriteString(token.String())
+		default:
+			b.Write(z.Raw())
+		}
+	}
+}
+
+// shorthand determines if any attribute is a shorthand.
+func shorthand(attrs []html.Attribute) bool {
+	for _, attr := range attrs {
+		if _, ok := shorthandPrefix(attr.Key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// shorthandPrefix returns the prefix of the vue attribute of the shorthand attribute.
+// Returns false for other attributes, including empty keys.
+func shorthandPrefix(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	prefix, ok := shorthands[key[:1]]
+	return prefix, ok
+}
+
+// member is a member of an object literal, e.g. name: value or a method shorthand.
+type member struct {
+	name  string
+	value string
+}
+
+// exportObject returns the body of the object literal exported by default.
+func exportObject(script string) string {
+	i := strings.Index(script, "export default")
+	if i < 0 {
+		return ""
+	}
+	j := strings.Index(script[i:], "{")
+	if j < 0 {
+		return ""
+	}
+	body, _ := balanced(script[i+j:])
+	return body
+}
+
+// value returns the value of the member of the object by name, e.g. props, data or methods.
+func value(object, name string) string {
+	for _, m := range members(object) {
+		if m.name == name {
+			return m.value
+		}
+	}
+	return ""
+}
+
+// returned returns the body of the object literal returned by the function, e.g. data() { return {...} }.
+func returned(function string) string {
+	i := strings.Index(function, "return")
+	if i < 0 {
+		return ""
+	}
+	rest := strings.TrimSpace(function[i+len("return"):])
+	if !strings.HasPrefix(rest, "{") {
+		return ""
+	}
+	body, _ := balanced(rest)
+	return body
+}
+
+// members splits the body of an object or array literal into its members.
+// Method shorthands, e.g. add() {...}, are members of their body.
+// Array elements are members of their unquoted value, e.g. props: ['title'].
+func members(literal string) []member {
+	literal = strings.TrimSpace(literal)
+	array := strings.HasPrefix(literal, "[")
+	if array || strings.HasPrefix(literal, "{") {
+		literal, _ = balanced(literal)
+	}
+
+	var ms []member
+	for _, part := range split(literal) {
+		if array {
+			name, err := unquote(part)
+			if err == nil {
+				ms = append(ms, member{name: name})
+			}
+			continue
+		}
+		if i := strings.IndexAny(part, ":({"); i > 0 && part[i] == ':' {
+			name, _ := unquote(strings.TrimSpace(part[:i]))
+			ms = append(ms, member{name: name, value: strings.TrimSpace(part[i+1:])})
+			continue
+		}
+		if i := strings.Index(part, "("); i > 0 {
+			name := strings.TrimSpace(strings.TrimPrefix(part[:i], "async "))
+			ms = append(ms, member{name: name, value: strings.TrimSpace(part[i:])})
+		}
+	}
+	return ms
+}
+
+// split splits the literal at top level commas.
+func split(literal string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(literal); i++ {
+		c := literal[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '{' || c == '[' || c == '(':
+			depth++
+		case c == '}' || c == ']' || c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(literal[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(literal[start:]); last != "" {
+		parts = append(parts, last)
+	}
+	return parts
+}
+
+// balanced returns the body between the opening bracket at the start of the text and its closing bracket,
+// then the rest after the closing bracket.
+func balanced(text string) (string, string) {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '{' || c == '[' || c == '(':
+			depth++
+		case c == '}' || c == ']' || c == ')':
+			depth--
+			if depth == 0 {
+				return text[1:i], text[i+1:]
+			}
+		}
+	}
+	return text[1:], ""
+}
+
+// number matches the literals of numbers.
+var number = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// literal returns the Go type and value of the JavaScript literal.
+// The value is empty for the zero value or values which are not literals.
+func literal(js string) (string, string) {
+	switch {
+	case js == "true" || js == "false":
+		return "bool", js
+	case number.MatchString(js):
+		if strings.Contains(js, ".") {
+			return "float64", js
+		}
+		return "int", js
+	case strings.HasPrefix(js, "["):
+		return "[]interface{}", ""
+	case strings.HasPrefix(js, "{"):
+		return "map[string]interface{}", ""
+	}
+	if text, err := unquote(js); err == nil {
+		return "string", strconv.Quote(text)
+	}
+	return "interface{}", ""
+}
+
+// unquote unquotes the JavaScript string literal or returns identifiers as is.
+func unquote(js string) (string, error) {
+	if len(js) >= 2 && (js[0] == '\'' || js[0] == '`') && js[len(js)-1] == js[0] {
+		js = `"` + strings.Replace(js[1:len(js)-1], `"`, `\"`, -1) + `"`
+	}
+	if strings.HasPrefix(js, `"`) {
+		return strconv.Unquote(js)
+	}
+	if js == "" || strings.ContainsAny(js, " ()[]{}") {
+		return "", fmt.Errorf("not a string literal: %s", js)
+	}
+	return js, nil
+}
+
+// quote quotes the text as a raw string when possible.
+func quote(text string) string {
+	if strings.Contains(text, "`") {
+		return strconv.Quote(text)
+	}
+	return "`\n" + text + "\n`"
+}
+
+// comment comments out the lines of the JavaScript source to port by hand.
+func comment(js string) string {
+	lines := strings.Split(strings.TrimSpace(js), "\n")
+	for i, line := range lines {
+		lines[i] = "// " + strings.TrimSpace(line)
+	}
+	return "// TODO: port from JavaScript.\n" + strings.Join(lines, "\n")
+}
+
+// identifier converts the name into an exported Go identifier, e.g. todo-item into TodoItem.
+func identifier(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+	})
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, "")
+}
+
+// unexported converts the exported identifier to unexported.
+func unexported(ident string) string {
+	return strings.ToLower(ident[:1]) + ident[1:]
+}
@@ -0,0 +1,48 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+)
+
+// slotElement is the element in templates of subcomponents which is filled by the children of the subcomponent element,
+// e.g. <app-button>Save</app-button> renders Save in place of the slot of the app-button template.
+// The children of the slot element are the fallback content when the subcomponent element is empty.
+const slotElement = "slot"
+
+// executeSlot executes the children of the subcomponent element within the scope of the parent,
+// which are removed from the element to fill the slot of the subcomponent.
+func (tmpl *template) executeSlot(node *html.Node, data map[string]interface{}) []*html.Node {
+	for child := node.FirstChild; child != nil; {
+		child = tmpl.executeElement(child, data)
+	}
+	tmpl.executeText(node, data)
+
+	slot := children(node)
+	for _, child := range slot {
+		node.RemoveChild(child)
+	}
+	return slot
+}
+
+// fillSlot recursively replaces the slot elements of the node with the slot,
+// otherwise with the fallback content of the slot element.
+func fillSlot(node *html.Node, slot []*html.Node) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		if child.Type != html.ElementNode || child.Data != slotElement {
+			fillSlot(child, slot)
+			child = next
+			continue
+		}
+
+		content := slot
+		if len(content) == 0 {
+			content = children(child)
+		}
+		for _, c := range content {
+			node.InsertBefore(cloneNode(c), child)
+		}
+		node.RemoveChild(child)
+		child = next
+	}
+}
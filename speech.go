@@ -0,0 +1,10 @@
+package vue
+
+// SpeechRecognition is the speech recognition option for root components.
+// Transcripts of recognized speech are streamed into the data field, including interim results.
+// Recognition is started by StartRecognition and stopped by StopRecognition or when the page is hidden.
+func SpeechRecognition(field string) Option {
+	return func(comp *Comp) {
+		comp.recognition = field
+	}
+}
@@ -0,0 +1,99 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"strings"
+	"syscall/js"
+)
+
+// speech is the speech recognition shared by components.
+var speech struct {
+	recognition js.Value
+	ready       bool
+	listening   bool
+}
+
+// StartRecognition starts to recognize speech from the microphone.
+// Browsers without the speech recognition api are ignored.
+func StartRecognition() {
+	if !speech.ready || speech.listening {
+		return
+	}
+	speech.recognition.Call("start")
+	speech.listening = true
+}
+
+// StopRecognition stops to recognize speech.
+func StopRecognition() {
+	if !speech.listening {
+		return
+	}
+	speech.recognition.Call("stop")
+	speech.listening = false
+}
+
+// Speak speaks the text by speech synthesis.
+// Speech is queued after previous speech unless canceled.
+func Speak(text string) {
+	synthesis := js.Global().Get("speechSynthesis")
+	if synthesis == js.Undefined() {
+		return
+	}
+	utterance := js.Global().Get("SpeechSynthesisUtterance").New(text)
+	synthesis.Call("speak", utterance)
+}
+
+// CancelSpeech cancels queued and current speech.
+func CancelSpeech() {
+	synthesis := js.Global().Get("speechSynthesis")
+	if synthesis == js.Undefined() {
+		return
+	}
+	synthesis.Call("cancel")
+}
+
+// watchRecognition streams transcripts of recognized speech into the data field.
+// Speech is stopped when the page is hidden.
+func (vm *ViewModel) watchRecognition() {
+	field := vm.comp.recognition
+	if field == "" || vm.comp.isSub {
+		return
+	}
+	constructor := js.Global().Get("SpeechRecognition")
+	if constructor == js.Undefined() {
+		constructor = js.Global().Get("webkitSpeechRecognition")
+	}
+	if constructor == js.Undefined() {
+		return
+	}
+
+	recognition := constructor.New()
+	recognition.Set("continuous", true)
+	recognition.Set("interimResults", true)
+	listen(recognition, "result", func(event js.Value) {
+		defer vm.report()
+		vm.Set(field, transcript(event.Get("results")))
+		vm.render()
+	})
+	listen(recognition, "end", func(js.Value) {
+		speech.listening = false
+	})
+	listen(js.Global(), "pagehide", func(js.Value) {
+		StopRecognition()
+		CancelSpeech()
+	})
+	speech.recognition = recognition
+	speech.ready = true
+}
+
+// transcript joins the transcripts of the most likely alternatives of the results.
+func transcript(results js.Value) string {
+	n := results.Length()
+	transcripts := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		transcripts = append(transcripts, strings.TrimSpace(results.Index(i).Index(0).Get("transcript").String()))
+	}
+	return strings.Join(transcripts, " ")
+}
@@ -0,0 +1,271 @@
+package vue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"golang.org/x/net/html"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// RenderToString renders the component with the data to html on the server, e.g. from an http handler for seo.
+// The data replaces the data option of the component, unless nil, e.g. a pointer to the struct of the page.
+// The html is the content of the element of the el option, which the component hydrates in the browser by the hydrate option.
+// Teleports are left to render in the browser, and hooks and watchers of the browser are not called.
+// The head is rendered by RenderWithHead.
+// Components are safe to render concurrently, since each render executes its own instances of the components.
+func RenderToString(comp *Comp, data interface{}) (string, error) {
+	var b strings.Builder
+	if err := RenderTo(&b, comp, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// RenderTo renders the component with the data to html on the server like RenderToString, streaming the html to the writer.
+// Subtrees are written as their execution completes, so large pages start arriving before the whole tree is executed.
+// Elements without vue attributes and subcomponents are opened before their children execute, e.g. the layout of a page.
+// Writers which flush are flushed after each subtree, e.g. an http.ResponseWriter.
+// Errors stop the stream, so the html written before is incomplete.
+func RenderTo(w io.Writer, comp *Comp, data interface{}) error {
+	_, err := render(w, comp, data)
+	return err
+}
+
+// RenderWithHead renders the component with the data to html on the server like RenderToString,
+// and the children of its head elements, e.g. the title and meta tags to render into the head of the page.
+func RenderWithHead(comp *Comp, data interface{}) (app, head string, err error) {
+	var b strings.Builder
+	s, err := render(&b, comp, data)
+	if err != nil {
+		return "", "", err
+	}
+	head, err = s.head()
+	return b.String(), head, err
+}
+
+// RenderWithState renders the component with the data to html and its head on the server like RenderWithHead,
+// and its state like RenderState from the data of the same render, e.g. to prerender pages which hydrate.
+func RenderWithState(comp *Comp, data interface{}) (app, head, state string, err error) {
+	var b strings.Builder
+	s, err := render(&b, comp, data)
+	if err != nil {
+		return "", "", "", err
+	}
+	if head, err = s.head(); err != nil {
+		return "", "", "", err
+	}
+	root := s.tmpl.comp
+	state, err = renderState(root.data, root.mixins)
+	return b.String(), head, state, err
+}
+
+// head renders the children of the teleported head elements.
+func (s *stream) head() (string, error) {
+	node, ok := s.teleported[headTarget]
+	if !ok {
+		return "", nil
+	}
+	// The last title takes precedence like in the browser.
+	dedupeHead(node)
+	if title, ok := headTitle(node); ok {
+		node.InsertBefore(newTitle(title), node.FirstChild)
+	}
+	var b strings.Builder
+	for _, child := range children(node) {
+		if err := html.Render(&b, child); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// RenderState renders the data fields of the component with the data as json on the server,
+// which is the value of the state attribute, data-vue-state, of the element of the el option.
+// Hydrating roots restore the state before their first render, so their markup matches the html of the server:
+//
+//	state, err := vue.RenderState(comp, page)
+//	fmt.Fprintf(w, `<div id="app" data-vue-state="%s">%s</div>`, html.EscapeString(state), app)
+//
+// The data replaces the data option of the component unless nil, like for RenderToString.
+func RenderState(comp *Comp, data interface{}) (string, error) {
+	if data == nil {
+		data = comp.data
+	}
+	return renderState(data, comp.mixins)
+}
+
+// renderState renders the data fields of the data and the mixins as json.
+func renderState(data interface{}, mixins []interface{}) (string, error) {
+	state := make(map[string]interface{})
+	for _, data := range append([]interface{}{data}, mixins...) {
+		val := reflect.Indirect(reflect.ValueOf(data))
+		if val.Kind() == reflect.Struct {
+			stateFields(val, state)
+		}
+	}
+	b, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// stateFields adds the exported fields of the struct to the state by name unless present,
+// then the fields promoted from embedded structs, like data fields are looked up.
+func stateFields(val reflect.Value, state map[string]interface{}) {
+	typ := val.Type()
+	var embedded []reflect.Value
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous {
+			if elem := reflect.Indirect(val.Field(i)); elem.Kind() == reflect.Struct {
+				embedded = append(embedded, elem)
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name := fieldName(field); state[name] == nil {
+			state[name] = val.Field(i).Interface()
+		}
+	}
+	for _, elem := range embedded {
+		stateFields(elem, state)
+	}
+}
+
+// render streams the html of the component with the data to the writer.
+// Panics of the execution are returned as errors.
+func render(w io.Writer, comp *Comp, data interface{}) (s *stream, err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		if err, _ = r.(error); err == nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	root := comp.clone()
+	if data != nil {
+		root.data = data
+	}
+	// The view model is not mounted, so neither hooks are queued nor flags subscribed.
+	vm := &ViewModel{comp: root, tmpl: newTemplate(root)}
+	root.vm, root.callback = vm, vm
+	vm.mapData()
+
+	s = &stream{w: w, tmpl: vm.tmpl, data: vm.data, teleported: make(map[string]*html.Node)}
+	s.children(vm.tmpl.node(vm))
+	return s, nil
+}
+
+// stream executes the template of the root component while writing completed subtrees.
+// Teleported children are kept by target instead.
+type stream struct {
+	w          io.Writer
+	tmpl       *template
+	data       map[string]interface{}
+	teleported map[string]*html.Node
+}
+
+// flusher is a writer which flushes, e.g. http.Flusher.
+type flusher interface {
+	Flush()
+}
+
+// rawText are the elements of which children are written unescaped.
+var rawText = map[string]struct{}{
+	"iframe": {}, "noembed": {}, "noframes": {}, "noscript": {}, "plaintext": {},
+	"script": {}, "style": {}, "textarea": {}, "title": {}, "xmp": {},
+}
+
+// children executes the children of the parent node in order and writes each subtree as it completes.
+// Executing a child may replace it, e.g. by the for attribute, so the completed subtrees are
+// the nodes from after the previous sibling until the next node to execute.
+func (s *stream) children(parent *html.Node) {
+	for child := parent.FirstChild; child != nil; {
+		if s.open(child) {
+			child = child.NextSibling
+			continue
+		}
+
+		prev := child.PrevSibling
+		next := s.tmpl.executeElement(child, s.data)
+		start := parent.FirstChild
+		if prev != nil {
+			start = prev.NextSibling
+		}
+		s.complete(start, next)
+		child = next
+	}
+}
+
+// open writes the start tag of the element before its children execute, then the end tag.
+// Elements with vue attributes, special elements and subcomponents are not opened, neither are void elements.
+func (s *stream) open(node *html.Node) bool {
+	if node.Type != html.ElementNode || !s.tmpl.plain(node) {
+		return false
+	}
+	if _, ok := rawText[node.Data]; ok {
+		return false
+	}
+
+	var b bytes.Buffer
+	tag := &html.Node{Type: html.ElementNode, Data: node.Data, DataAtom: node.DataAtom, Namespace: node.Namespace, Attr: node.Attr}
+	must(html.Render(&b, tag))
+	end := fmt.Sprintf("</%s>", node.Data)
+	if !bytes.HasSuffix(b.Bytes(), []byte(end)) {
+		return false
+	}
+
+	s.write(b.Bytes()[:b.Len()-len(end)])
+	s.children(node)
+	s.write([]byte(end))
+	return true
+}
+
+// complete executes the texts of the completed subtrees and writes them, except for teleports.
+func (s *stream) complete(start, next *html.Node) {
+	nodes := siblings(start, next)
+	if len(nodes) == 0 {
+		return
+	}
+	// Texts are split into chunks before the next node, so the subtrees are written after execution.
+	for _, node := range nodes {
+		s.tmpl.executeText(node, s.data)
+	}
+
+	var b bytes.Buffer
+	for _, node := range siblings(start, next) {
+		if teleportChild(node, s.teleported) {
+			continue
+		}
+		teleport(node, s.teleported)
+		must(html.Render(&b, node))
+	}
+	s.write(b.Bytes())
+	if f, ok := s.w.(flusher); ok {
+		f.Flush()
+	}
+}
+
+// write writes the html to the writer.
+func (s *stream) write(p []byte) {
+	_, err := s.w.Write(p)
+	must(err)
+}
+
+// siblings makes a slice of the nodes from the start until the next node.
+func siblings(start, next *html.Node) []*html.Node {
+	var nodes []*html.Node
+	for node := start; node != next; node = node.NextSibling {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
@@ -0,0 +1,22 @@
+package vue
+
+import (
+	"encoding/json"
+)
+
+// SnapshotState encodes the data of the component as json,
+// e.g. to send the state to the server or transfer it from server side rendering to the client.
+// The data is encoded by its json tags and custom marshalers.
+func (vm *ViewModel) SnapshotState() ([]byte, error) {
+	return json.Marshal(vm.comp.data)
+}
+
+// RestoreState decodes the json state into the data of the component then renders.
+// The state is decoded by the json tags and custom unmarshalers of the data, which must be a pointer.
+func (vm *ViewModel) RestoreState(state []byte) error {
+	if err := json.Unmarshal(state, vm.comp.data); err != nil {
+		return err
+	}
+	vm.render()
+	return nil
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// snapshot is the state of a store and its modules.
+type snapshot struct {
+	State   interface{}
+	Modules map[string]*snapshot
+}
+
+// SnapshotState encodes the state of the store and its modules as json, e.g. to persist the state.
+func (s *Store) SnapshotState() ([]byte, error) {
+	root := s.rootStore()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	return json.Marshal(capture(s))
+}
+
+// ReplaceState decodes the json state into the state of the store and its modules then renders,
+// e.g. to restore the persisted state.
+func (s *Store) ReplaceState(state []byte) error {
+	root := s.rootStore()
+	root.mu.Lock()
+	err := restore(s, state)
+	root.compute()
+	root.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if root.in != nil {
+		root.in.ForceUpdate()
+	}
+	return nil
+}
+
+// capture recursively captures the state of the store and its modules.
+func capture(s *Store) *snapshot {
+	snap := &snapshot{State: s.State, Modules: make(map[string]*snapshot, len(s.Modules))}
+	for name, m := range s.Modules {
+		snap.Modules[name] = capture(m)
+	}
+	return snap
+}
+
+// restore recursively restores the state of the store and its modules from the snapshot.
+// States are reset to their zero value before restoring, so fields absent from the snapshot are cleared.
+func restore(s *Store, b []byte) error {
+	var raw struct {
+		State   json.RawMessage
+		Modules map[string]json.RawMessage
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	state := reflect.ValueOf(s.State)
+	if state.Kind() != reflect.Ptr {
+		return fmt.Errorf("state is not a pointer: %T", s.State)
+	}
+	state.Elem().Set(reflect.Zero(state.Elem().Type()))
+	if err := json.Unmarshal(raw.State, s.State); err != nil {
+		return err
+	}
+	for name, m := range s.Modules {
+		if b, ok := raw.Modules[name]; ok {
+			if err := restore(m, b); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
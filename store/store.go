@@ -0,0 +1,327 @@
+// Package store is the central state of applications, which is shared by all components.
+// The state is changed by mutations, which are synchronous, and actions, which are asynchronous and commit mutations.
+// Getters compute values from the state. The store is installed as a plugin:
+//
+//	s := store.New(&State{},
+//		store.Mutation("add", func(state interface{}, payload interface{}) {
+//			state.(*State).Todos = append(state.(*State).Todos, payload.(string))
+//		}),
+//		store.Getter("count", func(state interface{}) interface{} {
+//			return len(state.(*State).Todos)
+//		}),
+//	)
+//	vue.Use(s)
+//
+// Components inject the store, e.g. vue.Inject("Store"), to read the state and getters in templates,
+// e.g. {{ Store.State.Todos }} or {{ Store.Getters.count }}, and to commit or dispatch from methods by From.
+// All components render after each mutation.
+//
+// Large stores are split into namespaced modules, e.g. user and cart, with their own state, mutations, actions and getters.
+// Mutations, actions and getters of modules are named by their namespace in the root store, e.g. cart/add,
+// while actions commit and dispatch within their module. The state and getters of modules are read by the module,
+// e.g. {{ Store.Modules.cart.State.Items }} or {{ Store.Modules.cart.Getters.total }}.
+//
+// Subscribers observe every mutation, e.g. to log, persist or track the state,
+// which are usually subscribed by store plugins.
+package store
+
+import (
+	"fmt"
+	"github.com/norunners/vue"
+	"sync"
+)
+
+// storeKey is the key of the provided store.
+const storeKey = "Store"
+
+// Option uses the option pattern for stores.
+type Option func(*Store)
+
+// Store is the central state with its mutations, actions and getters.
+type Store struct {
+	// State is the state, which must only be changed by mutations.
+	State interface{}
+	// Getters are the values of getters by name, which are computed after each mutation.
+	Getters map[string]interface{}
+	// Modules are the namespaced modules by name.
+	Modules map[string]*Store
+
+	root        *Store
+	namespace   string
+	plugins     []func(*Store)
+	subscribers map[int]func(Committed, interface{})
+	subscribed  int
+	mu          sync.Mutex
+	mutations   map[string]func(state interface{}, payload interface{})
+	actions     map[string]func(context *ActionContext, payload interface{}) error
+	getters     map[string]func(state interface{}) interface{}
+	in          *vue.Installer
+}
+
+// Committed is the mutation committed to the store, which is named by its namespace.
+type Committed struct {
+	Name    string
+	Payload interface{}
+}
+
+// ActionContext is received by actions to commit mutations and dispatch other actions.
+type ActionContext struct {
+	store *Store
+}
+
+// New creates a new store of the state, which should be a pointer to a struct.
+func New(state interface{}, options ...Option) *Store {
+	s := newStore(state, options...)
+	s.install()
+	return s
+}
+
+// newStore creates a new store of the state without calling its plugins.
+func newStore(state interface{}, options ...Option) *Store {
+	s := &Store{
+		State:     state,
+		Getters:   make(map[string]interface{}),
+		Modules:   make(map[string]*Store),
+		mutations: make(map[string]func(interface{}, interface{})),
+		actions:   make(map[string]func(*ActionContext, interface{}) error),
+		getters:   make(map[string]func(interface{}) interface{}),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	s.compute()
+	return s
+}
+
+// install calls the plugins of the store.
+func (s *Store) install() {
+	for _, plugin := range s.plugins {
+		plugin(s)
+	}
+}
+
+// Plugin is the plugin option for stores, which is called with the created store,
+// e.g. to subscribe to mutations or to restore the state.
+// Plugins of modules are called once the module is composed into its parent store.
+func Plugin(plugin func(s *Store)) Option {
+	return func(s *Store) {
+		s.plugins = append(s.plugins, plugin)
+	}
+}
+
+// Subscribe subscribes the function to every mutation, which is called after the mutation with the state of the root store.
+// Modules subscribe to the root store. The returned function unsubscribes.
+func (s *Store) Subscribe(fn func(mutation Committed, state interface{})) func() {
+	root := s.rootStore()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	if root.subscribers == nil {
+		root.subscribers = make(map[int]func(Committed, interface{}))
+	}
+	id := root.subscribed
+	root.subscribed++
+	root.subscribers[id] = fn
+	return func() {
+		root.mu.Lock()
+		defer root.mu.Unlock()
+		delete(root.subscribers, id)
+	}
+}
+
+// Mutation is the mutation option for stores.
+// Mutations change the state synchronously by the payload.
+func Mutation(name string, mutation func(state interface{}, payload interface{})) Option {
+	return func(s *Store) {
+		s.mutations[name] = mutation
+	}
+}
+
+// Action is the action option for stores.
+// Actions run asynchronously, e.g. to fetch data, then commit mutations by the context.
+func Action(name string, action func(context *ActionContext, payload interface{}) error) Option {
+	return func(s *Store) {
+		s.actions[name] = action
+	}
+}
+
+// Getter is the getter option for stores.
+// Getters compute values from the state, e.g. counts or filtered lists.
+func Getter(name string, getter func(state interface{}) interface{}) Option {
+	return func(s *Store) {
+		s.getters[name] = getter
+	}
+}
+
+// Module is the module option for stores.
+// The module is namespaced by name with its own state, mutations, actions, getters and modules.
+func Module(name string, state interface{}, options ...Option) Option {
+	return func(s *Store) {
+		m := newStore(state, options...)
+		m.root = s
+		m.namespace = name + "/"
+		s.Modules[name] = m
+		defer m.install()
+		for mutationName, mutation := range m.mutations {
+			mutation := mutation
+			s.mutations[m.namespace+mutationName] = func(_ interface{}, payload interface{}) {
+				mutation(m.State, payload)
+			}
+		}
+		for actionName, action := range m.actions {
+			action := action
+			s.actions[m.namespace+actionName] = func(_ *ActionContext, payload interface{}) error {
+				return action(&ActionContext{store: m}, payload)
+			}
+		}
+		for getterName, getter := range m.getters {
+			getter := getter
+			s.getters[m.namespace+getterName] = func(interface{}) interface{} {
+				return getter(m.State)
+			}
+		}
+	}
+}
+
+// Install provides the store to all components by the Store key.
+func (s *Store) Install(in *vue.Installer) {
+	s.in = in
+	in.Provide(storeKey, s)
+}
+
+// From returns the store injected into the component.
+func From(context vue.Context) *Store {
+	s, ok := context.Get(storeKey).(*Store)
+	if !ok {
+		panic(fmt.Errorf("store is not injected: %s", storeKey))
+	}
+	return s
+}
+
+// Commit commits the mutation by name with the payload, then renders.
+// Modules commit by the root store within their namespace.
+// Commit is safe to call from any goroutine, since installed stores apply the mutation before the next render,
+// so renders never read the state while it changes. Methods read the committed state once they return.
+func (s *Store) Commit(name string, payload interface{}) {
+	s.commit(name, payload, false)
+}
+
+// commit commits the mutation by name with the payload, optionally waiting until the mutation is applied.
+func (s *Store) commit(name string, payload interface{}, wait bool) {
+	if s.root != nil {
+		s.root.commit(s.namespace+name, payload, wait)
+		return
+	}
+	mutation, ok := s.mutations[name]
+	if !ok {
+		panic(fmt.Errorf("unknown mutation: %s", name))
+	}
+	if s.in == nil {
+		s.mutate(name, mutation, payload)
+		return
+	}
+
+	done := make(chan struct{})
+	s.in.Update(func() {
+		s.mutate(name, mutation, payload)
+		close(done)
+	})
+	if wait {
+		<-done
+	}
+}
+
+// mutate applies the mutation with the payload under the lock, then notifies the subscribers.
+func (s *Store) mutate(name string, mutation func(state interface{}, payload interface{}), payload interface{}) {
+	s.mu.Lock()
+	mutation(s.State, payload)
+	s.compute()
+	subscribers := make([]func(Committed, interface{}), 0, len(s.subscribers))
+	for id := 0; id < s.subscribed; id++ {
+		if subscriber, ok := s.subscribers[id]; ok {
+			subscribers = append(subscribers, subscriber)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber(Committed{Name: name, Payload: payload}, s.State)
+	}
+}
+
+// Dispatch dispatches the action by name with the payload, which runs on a goroutine.
+// Modules dispatch by the root store within their namespace.
+// The returned channel receives the error of the action, which is nil on success.
+func (s *Store) Dispatch(name string, payload interface{}) <-chan error {
+	if s.root != nil {
+		return s.root.Dispatch(s.namespace+name, payload)
+	}
+	action, ok := s.actions[name]
+	if !ok {
+		panic(fmt.Errorf("unknown action: %s", name))
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- action(&ActionContext{store: s}, payload)
+	}()
+	return errs
+}
+
+// compute recursively computes the values of getters from the state of the store and its modules.
+func (s *Store) compute() {
+	getters := make(map[string]interface{}, len(s.getters))
+	for name, getter := range s.getters {
+		getters[name] = getter(s.State)
+	}
+	s.Getters = getters
+	for _, m := range s.Modules {
+		m.compute()
+	}
+}
+
+// rootStore returns the root store of modules.
+func (s *Store) rootStore() *Store {
+	for s.root != nil {
+		s = s.root
+	}
+	return s
+}
+
+// State returns the state of the store or module of the action, which must only be changed by mutations.
+// Mutations may change the state concurrently with the action, so its fields are read by ReadState.
+func (context *ActionContext) State() interface{} {
+	return context.store.State
+}
+
+// ReadState calls the function with the state of the store or module of the action,
+// during which mutations do not change the state, e.g. to read fields after committing.
+func (context *ActionContext) ReadState(fn func(state interface{})) {
+	root := context.store.rootStore()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	fn(context.store.State)
+}
+
+// Getter returns the value of the getter of the store or module of the action by name.
+func (context *ActionContext) Getter(name string) interface{} {
+	root := context.store.rootStore()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	return context.store.Getters[name]
+}
+
+// Root returns the root store, e.g. to commit mutations of other modules.
+func (context *ActionContext) Root() *Store {
+	return context.store.rootStore()
+}
+
+// Commit commits the mutation by name with the payload, then waits until the mutation is applied,
+// so the action reads the committed state.
+func (context *ActionContext) Commit(name string, payload interface{}) {
+	context.store.commit(name, payload, true)
+}
+
+// Dispatch dispatches the action by name with the payload, then waits for its error.
+func (context *ActionContext) Dispatch(name string, payload interface{}) error {
+	return <-context.store.Dispatch(name, payload)
+}
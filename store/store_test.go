@@ -0,0 +1,202 @@
+package store
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type cartState struct {
+	Items []string
+}
+
+type userState struct {
+	Name string
+}
+
+type rootState struct {
+	Count int
+}
+
+// newTestStore creates a store with the cart and user modules, where the user module nests the prefs module.
+func newTestStore() *Store {
+	add := Mutation("add", func(state interface{}, payload interface{}) {
+		state.(*cartState).Items = append(state.(*cartState).Items, payload.(string))
+	})
+	return New(&rootState{},
+		Mutation("increment", func(state interface{}, payload interface{}) {
+			state.(*rootState).Count += payload.(int)
+		}),
+		Module("cart", &cartState{},
+			add,
+			Getter("total", func(state interface{}) interface{} {
+				return len(state.(*cartState).Items)
+			}),
+			Action("addTwice", func(context *ActionContext, payload interface{}) error {
+				context.Commit("add", payload)
+				context.Commit("add", payload)
+				return nil
+			}),
+			Action("fail", func(context *ActionContext, payload interface{}) error {
+				return errors.New("fail")
+			}),
+			Action("addAndCount", func(context *ActionContext, payload interface{}) error {
+				context.Commit("add", payload)
+				context.Root().Commit("increment", context.Getter("total").(int))
+				return nil
+			}),
+		),
+		Module("user", &userState{},
+			Mutation("rename", func(state interface{}, payload interface{}) {
+				state.(*userState).Name = payload.(string)
+			}),
+			Module("prefs", &cartState{}, add),
+		),
+	)
+}
+
+func TestCommit(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit func(s *Store)
+		items  []string
+		user   string
+		count  int
+		total  int
+		names  []string
+	}{
+		{
+			name:   "root",
+			commit: func(s *Store) { s.Commit("increment", 2) },
+			count:  2,
+			names:  []string{"increment"},
+		},
+		{
+			name:   "namespaced by root",
+			commit: func(s *Store) { s.Commit("cart/add", "milk") },
+			items:  []string{"milk"},
+			total:  1,
+			names:  []string{"cart/add"},
+		},
+		{
+			name:   "namespaced by module",
+			commit: func(s *Store) { s.Modules["cart"].Commit("add", "milk") },
+			items:  []string{"milk"},
+			total:  1,
+			names:  []string{"cart/add"},
+		},
+		{
+			name:   "same name in other module",
+			commit: func(s *Store) { s.Modules["user"].Modules["prefs"].Commit("add", "dark") },
+			names:  []string{"user/prefs/add"},
+		},
+		{
+			name: "modules are independent",
+			commit: func(s *Store) {
+				s.Commit("user/rename", "ann")
+				s.Commit("cart/add", "eggs")
+			},
+			items: []string{"eggs"},
+			user:  "ann",
+			total: 1,
+			names: []string{"user/rename", "cart/add"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestStore()
+			var names []string
+			s.Modules["cart"].Subscribe(func(mutation Committed, state interface{}) {
+				if _, ok := state.(*rootState); !ok {
+					t.Errorf("subscriber state is not the root state: %T", state)
+				}
+				names = append(names, mutation.Name)
+			})
+			test.commit(s)
+
+			cart := s.Modules["cart"]
+			if items := cart.State.(*cartState).Items; !reflect.DeepEqual(items, test.items) {
+				t.Errorf("got items %v, want %v", items, test.items)
+			}
+			if name := s.Modules["user"].State.(*userState).Name; name != test.user {
+				t.Errorf("got user %q, want %q", name, test.user)
+			}
+			if count := s.State.(*rootState).Count; count != test.count {
+				t.Errorf("got count %d, want %d", count, test.count)
+			}
+			if total := cart.Getters["total"]; total != test.total {
+				t.Errorf("got total %v, want %d", total, test.total)
+			}
+			if !reflect.DeepEqual(names, test.names) {
+				t.Errorf("got mutations %v, want %v", names, test.names)
+			}
+		})
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		dispatch func(s *Store) <-chan error
+		err      bool
+		items    []string
+		count    int
+	}{
+		{
+			name:     "commits within module",
+			dispatch: func(s *Store) <-chan error { return s.Modules["cart"].Dispatch("addTwice", "milk") },
+			items:    []string{"milk", "milk"},
+		},
+		{
+			name:     "namespaced by root",
+			dispatch: func(s *Store) <-chan error { return s.Dispatch("cart/addTwice", "eggs") },
+			items:    []string{"eggs", "eggs"},
+		},
+		{
+			name:     "commits to root after reading getter",
+			dispatch: func(s *Store) <-chan error { return s.Dispatch("cart/addAndCount", "milk") },
+			items:    []string{"milk"},
+			count:    1,
+		},
+		{
+			name:     "error",
+			dispatch: func(s *Store) <-chan error { return s.Dispatch("cart/fail", nil) },
+			err:      true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestStore()
+			if err := <-test.dispatch(s); (err != nil) != test.err {
+				t.Fatalf("got error %v, want error %v", err, test.err)
+			}
+			if items := s.Modules["cart"].State.(*cartState).Items; !reflect.DeepEqual(items, test.items) {
+				t.Errorf("got items %v, want %v", items, test.items)
+			}
+			if count := s.State.(*rootState).Count; count != test.count {
+				t.Errorf("got count %d, want %d", count, test.count)
+			}
+		})
+	}
+}
+
+func TestUnknown(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(s *Store)
+	}{
+		{"mutation", func(s *Store) { s.Commit("add", "milk") }},
+		{"module mutation", func(s *Store) { s.Modules["user"].Commit("add", "milk") }},
+		{"action", func(s *Store) { s.Dispatch("addTwice", "milk") }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			test.call(newTestStore())
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/norunners/vue"
+)
+
+// panelTemplate is the template of the time travel panel.
+const panelTemplate = `
+<div class="store-time-travel">
+  <button v-on:click="back">Back</button>
+  <button v-on:click="forward">Forward</button>
+  <span>{{ Position }}</span>
+  <ol>
+    <li v-for="Entry in Entries">{{#Entry.Current}}&#9654; {{/Entry.Current}}{{ Entry.Name }}</li>
+  </ol>
+</div>
+`
+
+// TimeTravel records the history of mutations with snapshots of the state,
+// then steps backward and forward through the states, e.g. to debug complex interactions.
+// Traveling renders the state at each point. Mutations after traveling back discard the states ahead.
+type TimeTravel struct {
+	store   *Store
+	entries []entry
+	current int
+	panel   *panel
+}
+
+// entry is a mutation in the history with the snapshot of the state after the mutation.
+type entry struct {
+	name     string
+	snapshot []byte
+}
+
+// panel is the data of the time travel panel.
+type panel struct {
+	Position string
+	Entries  []panelEntry
+}
+
+// panelEntry is an entry of the time travel panel.
+type panelEntry struct {
+	Name    string
+	Current bool
+}
+
+// initialState is the name of the entry of the state before any mutation.
+const initialState = "@init"
+
+// NewTimeTravel creates a new time travel of the store, which records from the current state.
+func NewTimeTravel(s *Store) *TimeTravel {
+	t := &TimeTravel{store: s.rootStore(), panel: &panel{}}
+	t.record(initialState)
+	t.store.Subscribe(func(mutation Committed, _ interface{}) {
+		t.record(mutation.Name)
+	})
+	return t
+}
+
+// Len returns the number of states in the history, including the initial state.
+func (t *TimeTravel) Len() int {
+	return len(t.entries)
+}
+
+// Current returns the index of the current state in the history.
+func (t *TimeTravel) Current() int {
+	return t.current
+}
+
+// Mutations returns the names of the mutations in the history, where the initial state is named @init.
+func (t *TimeTravel) Mutations() []string {
+	names := make([]string, len(t.entries))
+	for i, e := range t.entries {
+		names[i] = e.name
+	}
+	return names
+}
+
+// Back travels to the previous state, if any.
+func (t *TimeTravel) Back() {
+	if t.current > 0 {
+		t.Jump(t.current - 1)
+	}
+}
+
+// Forward travels to the next state, if any.
+func (t *TimeTravel) Forward() {
+	if t.current < len(t.entries)-1 {
+		t.Jump(t.current + 1)
+	}
+}
+
+// Jump travels to the state by index in the history, then renders.
+func (t *TimeTravel) Jump(i int) {
+	if i < 0 || i >= len(t.entries) {
+		panic(fmt.Errorf("state out of range of history: %d", i))
+	}
+	s := t.store
+	s.mu.Lock()
+	if err := restore(s, t.entries[i].snapshot); err != nil {
+		s.mu.Unlock()
+		panic(err)
+	}
+	s.compute()
+	t.current = i
+	t.update()
+	s.mu.Unlock()
+
+	if s.in != nil {
+		s.in.ForceUpdate()
+	}
+}
+
+// Panel creates the on-page panel of the time travel by the element, e.g. #time-travel,
+// which lists the mutations and steps backward and forward.
+func (t *TimeTravel) Panel(el string) *vue.ViewModel {
+	return vue.New(
+		vue.El(el),
+		vue.Template(panelTemplate),
+		vue.Data(t.panel),
+		vue.Methods(map[string]func(vue.Context){
+			"back": func(vue.Context) {
+				t.Back()
+			},
+			"forward": func(vue.Context) {
+				t.Forward()
+			},
+		}),
+	)
+}
+
+// record records the snapshot of the state after the mutation.
+// States ahead of the current state are discarded.
+func (t *TimeTravel) record(name string) {
+	b, err := json.Marshal(capture(t.store))
+	if err != nil {
+		panic(err)
+	}
+	if len(t.entries) > 0 {
+		t.entries = t.entries[:t.current+1]
+	}
+	t.entries = append(t.entries, entry{name: name, snapshot: b})
+	t.current = len(t.entries) - 1
+	t.update()
+}
+
+// update updates the data of the panel.
+func (t *TimeTravel) update() {
+	entries := make([]panelEntry, len(t.entries))
+	for i, e := range t.entries {
+		entries[i] = panelEntry{Name: e.name, Current: i == t.current}
+	}
+	*t.panel = panel{Position: fmt.Sprintf("%d / %d", t.current, len(t.entries)-1), Entries: entries}
+}
@@ -0,0 +1,66 @@
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+)
+
+// teleportElement is the element which renders its children into the element of the to selector elsewhere in the document,
+// e.g. <teleport to="#modals"><div class="modal">...</div></teleport>.
+// The children are executed by the component which owns the teleport, so their bindings and events are its own.
+// Teleported children are appended after the existing children of the target, which is outside of the root element.
+const teleportElement = "teleport"
+
+// teleportTo is the attribute of the teleport to the selector of the target.
+const teleportTo = "to"
+
+// teleport recursively removes the teleports from the node into their children by target.
+// Children of teleports to the same target are rendered in order.
+func teleport(node *html.Node, teleported map[string]*html.Node) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		if !teleportChild(child, teleported) {
+			teleport(child, teleported)
+		}
+		child = next
+	}
+}
+
+// teleportChild removes the child into the children of its target if the child is a teleport.
+// The head element is a teleport to the head of the document.
+func teleportChild(child *html.Node, teleported map[string]*html.Node) bool {
+	if child.Type != html.ElementNode {
+		return false
+	}
+	var to string
+	switch child.Data {
+	case teleportElement:
+		for _, attr := range child.Attr {
+			if attr.Key == teleportTo {
+				to = attr.Val
+			}
+		}
+		if to == "" {
+			must(fmt.Errorf("teleport element requires attribute: %s", teleportTo))
+		}
+	case headElement:
+		to = headTarget
+	default:
+		return false
+	}
+
+	dst, ok := teleported[to]
+	if !ok {
+		dst = &html.Node{Type: html.ElementNode}
+		teleported[to] = dst
+	}
+	if child.Parent != nil {
+		child.Parent.RemoveChild(child)
+	}
+	teleport(child, teleported)
+	for _, c := range children(child) {
+		child.RemoveChild(c)
+		dst.AppendChild(c)
+	}
+	return true
+}
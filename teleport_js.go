@@ -0,0 +1,89 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"fmt"
+	"github.com/gowasm/go-js-dom"
+	"golang.org/x/net/html"
+)
+
+// renderNode renders the executed node into the virtual dom.
+// The title of the head is set on the document, while the other teleported children are rendered into their targets,
+// and the targets of teleports which are no longer rendered are emptied.
+func (vm *ViewModel) renderNode(node *html.Node) {
+	teleported := make(map[string]*html.Node)
+	teleport(node, teleported)
+	vm.renderTitle(teleported)
+	vm.adoptHead(teleported)
+	if vm.hydrating {
+		vm.hydrating = false
+		vm.hydrateMismatch(node)
+	}
+	vm.vnode.render(node)
+
+	for to, target := range vm.teleports {
+		if _, ok := teleported[to]; !ok {
+			target.render(&html.Node{Type: html.ElementNode})
+		}
+	}
+	for to, src := range teleported {
+		vm.teleportTarget(to).render(src)
+	}
+}
+
+// teleportTarget returns the virtual node of the target of the selector, which is created on first use.
+// The virtual node owns only the teleported children of the target.
+// Event listeners of the root element are also added to the target.
+func (vm *ViewModel) teleportTarget(to string) *vnode {
+	if target, ok := vm.teleports[to]; ok {
+		return target
+	}
+	el := document.QuerySelector(to)
+	if el == nil {
+		must(fmt.Errorf("unknown teleport target: %s", to))
+	}
+	target := &vnode{typ: html.ElementNode, data: el.TagName(), attrs: map[string]string{}, node: el, patches: vm.vnode.patches}
+	for _, l := range vm.callbacks {
+		vm.listen(el, l.typ, vm.outside(l.cb))
+	}
+	if vm.teleports == nil {
+		vm.teleports = make(map[string]*vnode)
+	}
+	vm.teleports[to] = target
+	return target
+}
+
+// listener is an event listener of the root element.
+type listener struct {
+	typ string
+	cb  func(dom.Event)
+}
+
+// addTeleportListener adds the event listener to the targets of teleports.
+func (vm *ViewModel) addTeleportListener(typ string, cb func(dom.Event)) {
+	for _, target := range vm.teleports {
+		vm.listen(target.node, typ, vm.outside(cb))
+	}
+}
+
+// outside wraps the event listener of the root element for the targets of teleports, ignoring the events of the root element,
+// which its own listener handles, e.g. of targets which contain the root element like the body.
+func (vm *ViewModel) outside(cb func(dom.Event)) func(dom.Event) {
+	return func(event dom.Event) {
+		if target := event.Target(); target != nil && vm.comp.el.Contains(target) {
+			return
+		}
+		cb(event)
+	}
+}
+
+// listen adds the event listener to the node, which is removed once the root is unmounted.
+// Listeners capture events before the elements, so events stopped by the stop modifier still call methods.
+func (vm *ViewModel) listen(node dom.Node, typ string, cb func(dom.Event)) {
+	fn := node.AddEventListener(typ, true, cb)
+	vm.unlisten = append(vm.unlisten, func() {
+		node.RemoveEventListener(typ, true, fn)
+	})
+}
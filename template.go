@@ -8,24 +8,40 @@ import (
 	"golang.org/x/net/html/atom"
 	"io"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
 const (
-	v      = "v-"
-	vBind  = "v-bind"
-	vFor   = "v-for"
-	vHtml  = "v-html"
-	vIf    = "v-if"
-	vModel = "v-model"
-	vOn    = "v-on"
+	v           = "v-"
+	vBind       = "v-bind"
+	vBindIs     = "v-bind:is"
+	vFor        = "v-for"
+	vHtml       = "v-html"
+	vIf         = "v-if"
+	vModel      = "v-model"
+	vOn         = "v-on"
+	vTrack      = "v-track"
+	vFullscreen = "v-fullscreen"
+	vAsset      = "v-asset"
 )
 
-var attrOrder = []string{vFor, vIf, vModel, vOn, vBind, vHtml}
+const (
+	component = "component"
+	keepAlive = "keep-alive"
+)
+
+var attrOrder = []string{vFor, vIf, vModel, vOn, vTrack, vFullscreen, vAsset, vBind, vHtml}
 
 type template struct {
 	comp *Comp
 	id   int64
+	// effects counts the executions which have effects, e.g. of subcomponents, so their regions are not reused.
+	effects int
+	// originals are the unmapped values of the loop variables of the current execution by key.
+	originals map[string]reflect.Value
+	// positions count the elements of subcomponents of the current execution by element.
+	positions map[string]int
 }
 
 // newTemplate creates a new template.
@@ -34,15 +50,31 @@ func newTemplate(comp *Comp) *template {
 }
 
 // execute executes the template with the given data to be rendered.
-func (tmpl *template) execute(data map[string]interface{}) *html.Node {
-	node := parseNode(tmpl.comp.tmpl)
-
-	tmpl.executeElement(node, data)
-	executeText(node, data)
+// Templates execute by regions, which are reused unless their dependencies change.
+// Components with a render function execute the rendered node instead of the template.
+func (tmpl *template) execute(context Context, data map[string]interface{}) *html.Node {
+	tmpl.originals = nil
+	tmpl.positions = nil
+	node := tmpl.node(context)
+	if tmpl.comp.render != nil {
+		tmpl.executeElement(node, data)
+		tmpl.executeText(node, data)
+	} else {
+		tmpl.executeRegions(node, "", data)
+	}
+	tmpl.comp.applyTheme(node)
 
 	return node
 }
 
+// node returns the node of the template to be executed.
+func (tmpl *template) node(context Context) *html.Node {
+	if tmpl.comp.render != nil {
+		return tmpl.comp.renderNode(context)
+	}
+	return compileTemplate(tmpl.comp.tmpl)
+}
+
 // executeElement recursively traverses the html node and templates the elements.
 // The next node is always returned which allows execution to jump around as needed.
 func (tmpl *template) executeElement(node *html.Node, data map[string]interface{}) *html.Node {
@@ -51,8 +83,25 @@ func (tmpl *template) executeElement(node *html.Node, data map[string]interface{
 		return node.NextSibling
 	}
 
+	// Execute the keep alive element in place of its dynamic component.
+	if node.Data == keepAlive {
+		tmpl.effects++
+		return tmpl.executeKeepAlive(node, data)
+	}
+
+	// Execute the children of the error boundary in place of the element.
+	if node.Data == errorBoundary {
+		tmpl.effects++
+		return tmpl.executeErrorBoundary(node, data)
+	}
+
+	// Execute the transition group as the element of its tag.
+	if node.Data == transitionGroup {
+		executeTransitionGroup(node)
+	}
+
 	// Attempt to create a subcomponent from the element.
-	sub, ok := tmpl.comp.newSub(node.Data)
+	sub, ok := tmpl.newSub(node, data)
 
 	// Order attributes before execution.
 	orderAttrs(node)
@@ -71,10 +120,23 @@ func (tmpl *template) executeElement(node *html.Node, data map[string]interface{
 		}
 	}
 
+	// Scope refs by the instance of the component.
+	tmpl.comp.scopeRef(node)
+
 	// Execute subcomponent.
 	if ok {
-		vm := newViewModel(sub)
-		subNode := vm.executeSub()
+		tmpl.effects++
+		tmpl.comp.callback.mount(sub, node.Data)
+		sub.bindStaticProps(node)
+		sub.checkProps()
+		sub.attrs = attrMap(node)
+		slot := tmpl.executeSlot(node, data)
+		subNode := sub.executeCaptured()
+		fillSlot(subNode, slot)
+		if sub.inherit {
+			inheritAttrs(subNode, node.Attr)
+		}
+		keyFragment(subNode, node.Attr)
 		children := children(subNode)
 		for _, child := range children {
 			subNode.RemoveChild(child)
@@ -93,20 +155,144 @@ func (tmpl *template) executeElement(node *html.Node, data map[string]interface{
 	return node.NextSibling
 }
 
+// newSub attempts to create a subcomponent from the element.
+// The component element resolves the subcomponent dynamically from the is binding.
+// Creation is deferred for the for attribute which executes each item of the element again.
+func (tmpl *template) newSub(node *html.Node, data map[string]interface{}) (*Comp, bool) {
+	if hasAttr(node, vFor) {
+		return nil, false
+	}
+	if node.Data != component {
+		if _, ok := tmpl.comp.subs[node.Data]; !ok && registry[node.Data] == nil {
+			return nil, false
+		}
+		return tmpl.comp.newSub(node.Data, tmpl.position(node, node.Data, data))
+	}
+
+	name := componentIs(node, data)
+	sub, ok := tmpl.comp.newSub(name, tmpl.position(node, name, data))
+	if !ok {
+		must(fmt.Errorf("unknown component: %s", name))
+	}
+	return sub, true
+}
+
+// position returns the position of the instance of the subcomponent element within the template,
+// which is its key if bound, e.g. of the items of a for attribute, so instances follow their items when reordered.
+// Otherwise the position is the count of the preceding elements of the subcomponent in the current execution.
+func (tmpl *template) position(node *html.Node, element string, data map[string]interface{}) string {
+	for _, attr := range node.Attr {
+		switch attr.Key {
+		case key:
+			return key + "=" + attr.Val
+		case vBind + ":" + key:
+			if value, ok := tmpl.lookup(data, attr.Val); ok {
+				return fmt.Sprintf("%s=%v", key, value)
+			}
+		}
+	}
+	if tmpl.positions == nil {
+		tmpl.positions = make(map[string]int)
+	}
+	n := tmpl.positions[element]
+	tmpl.positions[element]++
+	return strconv.Itoa(n)
+}
+
+// componentIs resolves the name of the dynamic component from the is binding.
+// The is binding is removed from the node.
+func componentIs(node *html.Node, data map[string]interface{}) string {
+	for i, attr := range node.Attr {
+		if attr.Key != vBindIs {
+			continue
+		}
+		deleteAttr(node, i)
+
+		value, ok := resolvePath(data, attr.Val)
+		if !ok {
+			must(fmt.Errorf("unknown data field: %s", attr.Val))
+		}
+		name, ok := value.(string)
+		if !ok {
+			must(fmt.Errorf("data field is not of type string: %T", value))
+		}
+		return name
+	}
+	must(fmt.Errorf("component element requires attribute: %s", vBindIs))
+	return ""
+}
+
+// executeKeepAlive executes the keep alive element.
+// Components visited by the dynamic component are kept rendered but hidden while inactive.
+// Keeping the elements preserves the state of the dom, e.g. inputs and scroll.
+func (tmpl *template) executeKeepAlive(node *html.Node, data map[string]interface{}) *html.Node {
+	var dynamic *html.Node
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.Data == component {
+			dynamic = child
+			break
+		}
+	}
+	if dynamic == nil {
+		must(fmt.Errorf("keep-alive element requires a child element: %s", component))
+	}
+
+	name := componentIs(dynamic, data)
+	tmpl.comp.keepAlive(name)
+	for _, kept := range tmpl.comp.alive {
+		attrs := make([]html.Attribute, len(dynamic.Attr))
+		copy(attrs, dynamic.Attr)
+		elem := &html.Node{Type: html.ElementNode, Data: kept, Attr: attrs}
+		node.Parent.InsertBefore(elem, node)
+		prev := elem.PrevSibling
+
+		tmpl.executeElement(elem, data)
+		if kept == name {
+			continue
+		}
+
+		// Hide the executed elements of the inactive component.
+		first := node.Parent.FirstChild
+		if prev != nil {
+			first = prev.NextSibling
+		}
+		for hidden := first; hidden != node; hidden = hidden.NextSibling {
+			if hidden.Type == html.ElementNode && !hasAttr(hidden, "hidden") {
+				hidden.Attr = append(hidden.Attr, html.Attribute{Key: "hidden"})
+			}
+		}
+	}
+
+	next := node.NextSibling
+	node.Parent.RemoveChild(node)
+	return next
+}
+
 // executeText recursively executes the text node.
-func executeText(node *html.Node, data map[string]interface{}) {
+// Texts without interpolations skip rendering and large texts are split into chunks.
+func (tmpl *template) executeText(node *html.Node, data map[string]interface{}) {
 	switch node.Type {
 	case html.TextNode:
-		if strings.TrimSpace(node.Data) == "" {
+		if !strings.Contains(node.Data, "{{") {
+			chunkText(node)
 			return
 		}
 
+		text := strings.TrimSpace(node.Data)
+		defer tmpl.wrapErr(node, "", text, text)
+		tmpl.executeTranslations(node, data)
+		tmpl.executePipes(node, data)
+		// Text nodes hold unescaped text, which is escaped once rendered to html, so interpolations are not escaped.
 		var err error
-		node.Data, err = mustache.Render(node.Data, data)
+		node.Data, err = mustache.RenderRaw(node.Data, true, data)
 		must(err)
+		chunkText(node)
 	case html.ElementNode:
-		for child := node.FirstChild; child != nil; child = child.NextSibling {
-			executeText(child, data)
+		// The next child is taken first to skip the chunks of the child.
+		for child := node.FirstChild; child != nil; {
+			next := child.NextSibling
+			tmpl.executeText(child, data)
+			child = next
 		}
 	}
 }
@@ -114,6 +300,8 @@ func executeText(node *html.Node, data map[string]interface{}) {
 // executeAttr executes the given vue attribute.
 // The next node will be executed next if the html was modified unless it is nil.
 func (tmpl *template) executeAttr(node *html.Node, sub *Comp, attr html.Attribute, data map[string]interface{}) (*html.Node, bool) {
+	source := fmt.Sprintf("%s=%q", attr.Key, attr.Val)
+	defer tmpl.wrapErr(node, attr.Key, fmt.Sprintf("<%s %s>", node.Data, source), source)
 	vals := strings.Split(attr.Key, ":")
 	typ, part := vals[0], ""
 	if len(vals) > 1 {
@@ -123,33 +311,46 @@ func (tmpl *template) executeAttr(node *html.Node, sub *Comp, attr html.Attribut
 	var modified bool
 	switch typ {
 	case vBind:
-		executeAttrBind(node, sub, part, attr.Val, data)
+		tmpl.executeAttrBind(node, sub, part, attr.Val, data)
 	case vFor:
 		next, modified = tmpl.executeAttrFor(node, attr.Val, data)
 	case vHtml:
-		executeAttrHtml(node, attr.Val, data)
+		tmpl.executeAttrHtml(node, attr.Val, data)
 	case vIf:
 		next, modified = tmpl.executeAttrIf(node, attr.Val, data)
 	case vModel:
-		tmpl.executeAttrModel(node, attr.Val, data)
+		if sub != nil {
+			tmpl.executeModelProp(sub, part, attr.Val, data)
+			break
+		}
+		tmpl.executeAttrModel(node, part, attr.Val, data)
 	case vOn:
 		tmpl.executeAttrOn(node, part, attr.Val)
+	case vTrack:
+		tmpl.executeAttrTrack(node, attr.Val)
+	case vFullscreen:
+		tmpl.executeAttrFullscreen(node, attr.Val)
+	case vAsset:
+		tmpl.executeAttrAsset(node, part, attr.Val)
 	default:
-		must(fmt.Errorf("unknown vue attribute: %v", typ))
+		if !tmpl.executeAttrDirective(node, typ, part, attr.Val, data) {
+			must(fmt.Errorf("unknown vue attribute: %v", typ))
+		}
 	}
 	return next, modified
 }
 
 // executeAttrBind executes the vue bind attribute.
-func executeAttrBind(node *html.Node, sub *Comp, key, value string, data map[string]interface{}) {
-	field, ok := data[value]
+func (tmpl *template) executeAttrBind(node *html.Node, sub *Comp, key, value string, data map[string]interface{}) {
+	field, ok := tmpl.lookup(data, value)
 	if !ok {
-		must(fmt.Errorf("unknown data field: %s", value))
+		return
 	}
 
-	prop := strings.Title(key)
-	if sub.hasProp(prop) {
-		sub.props[prop] = field
+	// Props are bound to the underlying values of formatted values,
+	// or to the original values for typed props, e.g. of nested structs.
+	if prop, ok := sub.propName(key); ok {
+		sub.bindProp(prop, tmpl.propValue(sub, prop, value, unformat(field)))
 		return
 	}
 
@@ -167,9 +368,11 @@ func (tmpl *template) executeAttrFor(node *html.Node, value string, data map[str
 	name := bytes.TrimSpace([]byte(vals[0]))
 	field := strings.TrimSpace(vals[1])
 
-	slice, ok := data[field]
+	slice, ok := tmpl.lookup(data, field)
 	if !ok {
-		must(fmt.Errorf("slice not found for field: %s", field))
+		next := node.NextSibling
+		node.Parent.RemoveChild(node)
+		return next, true
 	}
 
 	elem := bytes.NewBuffer(nil)
@@ -178,6 +381,8 @@ func (tmpl *template) executeAttrFor(node *html.Node, value string, data map[str
 
 	buf := bytes.NewBuffer(nil)
 	values := reflect.ValueOf(slice)
+	originals, ok := tmpl.originalValue(field)
+	ok = ok && (originals.Kind() == reflect.Slice || originals.Kind() == reflect.Array) && originals.Len() == values.Len()
 	n := values.Len()
 	for i := 0; i < n; i++ {
 		key := fmt.Sprintf("%s%d", name, tmpl.id)
@@ -188,22 +393,32 @@ func (tmpl *template) executeAttrFor(node *html.Node, value string, data map[str
 		must(err)
 
 		data[key] = values.Index(i).Interface()
+		if ok {
+			if tmpl.originals == nil {
+				tmpl.originals = make(map[string]reflect.Value)
+			}
+			tmpl.originals[key] = originals.Index(i)
+		}
 	}
 
+	next := node.NextSibling
 	nodes := parseNodes(buf)
 	for _, child := range nodes {
 		node.Parent.InsertBefore(child, node)
 	}
 	node.Parent.RemoveChild(node)
-	// The first child is the next node to execute.
+	// The first child is the next node to execute, otherwise the next sibling of empty loops.
+	if len(nodes) == 0 {
+		return next, true
+	}
 	return nodes[0], true
 }
 
 // executeAttrHtml executes the vue html attribute.
-func executeAttrHtml(node *html.Node, field string, data map[string]interface{}) {
-	value, ok := data[field]
+func (tmpl *template) executeAttrHtml(node *html.Node, field string, data map[string]interface{}) {
+	value, ok := tmpl.lookup(data, field)
 	if !ok {
-		must(fmt.Errorf("unknown data field: %s", field))
+		return
 	}
 	html, ok := value.(string)
 	if !ok {
@@ -217,8 +432,13 @@ func executeAttrHtml(node *html.Node, field string, data map[string]interface{})
 }
 
 // executeAttrIf executes the vue if attribute.
+// Feature flags are queried by the flag function.
 func (tmpl *template) executeAttrIf(node *html.Node, field string, data map[string]interface{}) (*html.Node, bool) {
-	if value, ok := data[field]; ok {
+	if name, ok := flagName(field); ok {
+		if tmpl.comp.callback.flag(name) {
+			return nil, false
+		}
+	} else if value, ok := resolvePath(data, field); ok {
 		if val, ok := value.(bool); ok && val {
 			return nil, false
 		}
@@ -229,26 +449,102 @@ func (tmpl *template) executeAttrIf(node *html.Node, field string, data map[stri
 }
 
 // executeAttrModel executes the vue model attribute.
-func (tmpl *template) executeAttrModel(node *html.Node, field string, data map[string]interface{}) {
-	typ := "input"
-	node.Attr = append(node.Attr, html.Attribute{Key: typ, Val: field})
-	tmpl.comp.callback.addEventListener(typ, tmpl.comp.callback.vModel)
-
-	value, ok := data[field]
+// Number and currency formats bind a float64 field displayed in the locale of the user,
+// e.g. v-model:currency="Price" currency="EUR". Formatted fields are updated on change.
+// Bool fields bind the checked state of checkboxes.
+// Fields of subcomponents which are props are set by their parent, see executeModelProp.
+func (tmpl *template) executeAttrModel(node *html.Node, format, field string, data map[string]interface{}) {
+	value, ok := tmpl.lookup(data, field)
 	if !ok {
-		must(fmt.Errorf("unknown data field: %s", field))
+		value = ""
+	}
+	checked, isBool := value.(bool)
+
+	typ := "input"
+	if format != "" || isBool {
+		typ = "change"
 	}
+	node.Attr = append(node.Attr, html.Attribute{Key: typ, Val: tmpl.comp.modelKey(field)})
+	tmpl.comp.callback.addEventListener(vModel, typ)
+
+	switch format {
+	case "":
+		if isBool {
+			node.Attr = append(node.Attr, html.Attribute{Key: modelFormat, Val: formatChecked})
+			if checked {
+				node.Attr = append(node.Attr, html.Attribute{Key: "checked"})
+			}
+			return
+		}
+	case formatNumber, formatCurrency:
+		number, ok := value.(float64)
+		if !ok {
+			must(fmt.Errorf("data field is not of type float64: %T", value))
+		}
+		currency := defaultCurrency
+		if i := indexAttr(node, currencyAttr); i >= 0 {
+			currency = node.Attr[i].Val
+		}
+		node.Attr = append(node.Attr,
+			html.Attribute{Key: modelFormat, Val: format},
+			html.Attribute{Key: "value", Val: formatLocale(number, format, currency)})
+		return
+	default:
+		must(fmt.Errorf("unknown model format: %s", format))
+	}
+
 	val, ok := value.(string)
 	if !ok {
 		must(fmt.Errorf("data field is not of type string: %T", field))
 	}
+
+	// Editable elements bind the sanitized html as children.
+	if isContentEditable(attrMap(node)) {
+		nodes := parseNodes(strings.NewReader(sanitize(val)))
+		for _, child := range nodes {
+			node.AppendChild(child)
+		}
+		return
+	}
 	node.Attr = append(node.Attr, html.Attribute{Key: "value", Val: val})
 }
 
 // executeAttrOn executes the vue on attribute.
-func (tmpl *template) executeAttrOn(node *html.Node, typ, method string) {
-	node.Attr = append(node.Attr, html.Attribute{Key: typ, Val: method})
-	tmpl.comp.callback.addEventListener(typ, tmpl.comp.callback.vOn)
+// Modifiers follow the type of the event, e.g. v-on:submit.prevent="Save".
+// Methods of subcomponents are called on the instance which rendered the element.
+func (tmpl *template) executeAttrOn(node *html.Node, part, method string) {
+	modifiers := strings.Split(part, ".")
+	typ := modifiers[0]
+	for _, modifier := range modifiers[1:] {
+		key, ok := modifierAttrs[modifier]
+		if !ok {
+			must(fmt.Errorf("unknown event modifier: %s", modifier))
+		}
+		addModifier(node, key, typ)
+	}
+	node.Attr = append(node.Attr, html.Attribute{Key: typ, Val: tmpl.comp.methodKey(method)})
+	tmpl.comp.callback.addEventListener(vOn, typ)
+}
+
+// plain determines if the element is executed as is, apart from its children.
+// Elements with vue attributes, special elements and subcomponents are not plain.
+func (tmpl *template) plain(node *html.Node) bool {
+	switch node.Data {
+	case keepAlive, errorBoundary, transitionGroup, component, teleportElement, headElement:
+		return false
+	}
+	if _, ok := tmpl.comp.subs[node.Data]; ok {
+		return false
+	}
+	if _, ok := registry[node.Data]; ok {
+		return false
+	}
+	for _, attr := range node.Attr {
+		if strings.HasPrefix(attr.Key, v) {
+			return false
+		}
+	}
+	return true
 }
 
 // parseNode parses the template into an html node.
@@ -287,7 +583,12 @@ func orderAttrs(node *html.Node) {
 			}
 		}
 	}
-	// Append other attributes which are not vue attributes.
+	// Append custom directives then other attributes which are not vue attributes.
+	for _, attr := range node.Attr {
+		if strings.HasPrefix(attr.Key, v) && !builtinAttr(attr.Key) {
+			attrs = append(attrs, attr)
+		}
+	}
 	for _, attr := range node.Attr {
 		if !strings.HasPrefix(attr.Key, v) {
 			attrs = append(attrs, attr)
@@ -296,12 +597,41 @@ func orderAttrs(node *html.Node) {
 	node.Attr = attrs
 }
 
+// builtinAttr determines if the key is of a built in vue attribute.
+func builtinAttr(key string) bool {
+	for _, prefix := range attrOrder {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // deleteAttr deletes the attribute of the node at the index.
 // Attribute order is preserved.
 func deleteAttr(node *html.Node, i int) {
 	node.Attr = append(node.Attr[:i], node.Attr[i+1:]...)
 }
 
+// hasAttr determines if the node has the attribute.
+func hasAttr(node *html.Node, key string) bool {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// attrMap makes a map of the attributes of the node.
+func attrMap(node *html.Node) map[string]string {
+	attrs := make(map[string]string, len(node.Attr))
+	for _, attr := range node.Attr {
+		attrs[attr.Key] = attr.Val
+	}
+	return attrs
+}
+
 // children makes a slice of child html nodes.
 func children(node *html.Node) []*html.Node {
 	children := make([]*html.Node, 0)
@@ -0,0 +1,24 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"testing"
+)
+
+func TestForEmpty(t *testing.T) {
+	comp := Component(
+		Template(`<div><ul><li v-for="todo in Todos">{{ todo }}</li></ul><p>{{ Count }}</p></div>`),
+		Data(&regionData{Todos: []string{"milk"}}),
+	)
+	vm := Headless(comp, nil)
+	vm.Splice("Todos", 0, 1)
+	if got, want := vm.HTML(), "<div><ul></ul><p>0</p></div>"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	vm.Push("Todos", "eggs")
+	if got, want := vm.HTML(), "<div><ul><li>eggs</li></ul><p>0</p></div>"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,28 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+	"strings"
+	"unicode/utf8"
+)
+
+// textChunk is the maximum size of text nodes, so large texts, e.g. logs, update by chunk.
+const textChunk = 16 * 1024
+
+// chunkText splits the large text node into sibling text nodes of at most the chunk size.
+// Texts are split after newlines where possible, otherwise between runes.
+func chunkText(node *html.Node) {
+	for len(node.Data) > textChunk && node.Parent != nil {
+		i := strings.LastIndexByte(node.Data[:textChunk], '\n') + 1
+		if i == 0 {
+			i = textChunk
+			for !utf8.RuneStart(node.Data[i]) {
+				i--
+			}
+		}
+		next := &html.Node{Type: html.TextNode, Data: node.Data[i:]}
+		node.Data = node.Data[:i]
+		node.Parent.InsertBefore(next, node.NextSibling)
+		node = next
+	}
+}
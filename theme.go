@@ -0,0 +1,42 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+	"strings"
+)
+
+// Theme is the theme option for components.
+// The tokens of the theme are set as css custom properties on the root elements of the component,
+// e.g. Theme(map[string]string{"vue-rich-text-border": "1px solid teal"}) sets --vue-rich-text-border.
+// Custom properties are inherited, so the theme of a root component restyles the built in components
+// without forking their templates, e.g. the tokens of RichText.
+func Theme(tokens map[string]string) Option {
+	return func(comp *Comp) {
+		if comp.theme == nil {
+			comp.theme = make(map[string]string, len(tokens))
+		}
+		for name, value := range tokens {
+			comp.theme[strings.TrimPrefix(name, "--")] = value
+		}
+	}
+}
+
+// applyTheme sets the tokens of the theme of the component on the root elements of the node.
+// Tokens precede the style of the element, which may refer to them.
+func (comp *Comp) applyTheme(node *html.Node) {
+	if len(comp.theme) == 0 {
+		return
+	}
+	var b strings.Builder
+	for _, name := range sortedKeys(comp.theme) {
+		b.WriteString("--" + name + ": " + comp.theme[name] + "; ")
+	}
+	tokens := b.String()
+	for _, root := range rootElements(node) {
+		if i := indexAttr(root, "style"); i >= 0 {
+			root.Attr[i].Val = tokens + root.Attr[i].Val
+			continue
+		}
+		root.Attr = append(root.Attr, html.Attribute{Key: "style", Val: strings.TrimSpace(tokens)})
+	}
+}
@@ -0,0 +1,62 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+	"time"
+)
+
+// Throttle is the throttle option for components.
+// The component updates at most once per interval, e.g. time.Second/10 for 10fps,
+// which coalesces intermediate states of frequently changing data, e.g. a log viewer.
+// The latest state is rendered once the interval elapses.
+func Throttle(interval time.Duration) Option {
+	return func(comp *Comp) {
+		comp.throttle = interval
+	}
+}
+
+// throttled returns the previously executed node of the subcomponent within the throttle interval.
+// A render is scheduled for when the interval elapses.
+// Returns false when the subcomponent may execute.
+func (sub *Comp) throttled() (*html.Node, bool) {
+	if sub.throttle <= 0 || sub.throttledNode == nil {
+		return nil, false
+	}
+	wait := sub.throttle - time.Since(sub.throttledAt)
+	if wait <= 0 {
+		return nil, false
+	}
+
+	if !sub.trailing {
+		sub.trailing = true
+		time.AfterFunc(wait, func() {
+			sub.callback.update(func() {
+				sub.trailing = false
+			})
+		})
+	}
+	return cloneNode(sub.throttledNode), true
+}
+
+// executeThrottled executes the subcomponent unless throttled.
+func (sub *Comp) executeThrottled(execute func() *html.Node) *html.Node {
+	if node, ok := sub.throttled(); ok {
+		sub.reused = sub.renders()
+		return node
+	}
+	node := execute()
+	if sub.throttle > 0 {
+		sub.throttledAt = time.Now()
+		sub.throttledNode = cloneNode(node)
+	}
+	return node
+}
+
+// throttleWait returns how long the root view model must wait before rendering again.
+// The lock of the view model must be held.
+func (vm *ViewModel) throttleWait() time.Duration {
+	if vm.comp.throttle <= 0 {
+		return 0
+	}
+	return vm.comp.throttle - time.Since(vm.flushed)
+}
@@ -0,0 +1,43 @@
+package vue
+
+import (
+	"golang.org/x/net/html"
+)
+
+// transitionGroup is the element which animates its keyed children, e.g. the items of a for loop,
+// by the classes of its name, which is v by default:
+//
+//	<transition-group name="list" tag="ul">
+//		<li v-for="item in Items" key="{{ item.ID }}">{{ item.Name }}</li>
+//	</transition-group>
+//
+// Entering children have the list-enter, list-enter-active then list-enter-to classes,
+// while leaving children have the list-leave, list-leave-active then list-leave-to classes
+// and are removed once their transition ends.
+// Moved children are transformed from their previous position then transition into place with the list-move class,
+// e.g. .list-move { transition: transform 0.3s; }.
+// The group renders as the element of the tag attribute, which is span by default.
+const transitionGroup = "transition-group"
+
+// transitionAttr is the attribute of the element rendered by the transition group to the name of its classes.
+const transitionAttr = "data-transition-group"
+
+// executeTransitionGroup replaces the transition group with the element of its tag,
+// which is marked by the name of the transition.
+func executeTransitionGroup(node *html.Node) {
+	node.Data = "span"
+	name := "v"
+	for i := 0; i < len(node.Attr); i++ {
+		switch attr := node.Attr[i]; attr.Key {
+		case "tag":
+			node.Data = attr.Val
+		case "name":
+			name = attr.Val
+		default:
+			continue
+		}
+		deleteAttr(node, i)
+		i--
+	}
+	node.Attr = append(node.Attr, html.Attribute{Key: transitionAttr, Val: name})
+}
@@ -0,0 +1,146 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"golang.org/x/net/html"
+	"strconv"
+	"strings"
+	"syscall/js"
+	"time"
+)
+
+// transition animates the children of a transition group for a render.
+type transition struct {
+	name  string
+	rects map[*vnode]js.Value
+}
+
+// transition returns the transition of the element for a render, or nil for elements which are not transition groups.
+// The positions of the children are recorded before the dom is patched.
+func (dst *vnode) transition() *transition {
+	name, ok := dst.attrs[transitionAttr]
+	if !ok {
+		return nil
+	}
+	t := &transition{name: name, rects: make(map[*vnode]js.Value)}
+	children := dst.elements()
+	dst.patches.do(func() {
+		for _, child := range children {
+			t.rects[child] = child.node.Underlying().Call("getBoundingClientRect")
+		}
+	})
+	return t
+}
+
+// elements returns the element children of the node.
+func (dst *vnode) elements() []*vnode {
+	var elements []*vnode
+	for child := dst.firstChild; child != nil; child = child.nextSibling {
+		if child.typ == html.ElementNode {
+			elements = append(elements, child)
+		}
+	}
+	return elements
+}
+
+// move transitions the children of the element which were moved by the patches into place.
+// Moved children are inverted to their previous position, then the transform is removed with the move class.
+func (t *transition) move(dst *vnode) {
+	if t == nil {
+		return
+	}
+	children := dst.elements()
+	dst.patches.do(func() {
+		var moved []js.Value
+		for _, child := range children {
+			before, ok := t.rects[child]
+			if !ok {
+				continue
+			}
+			el := child.node.Underlying()
+			after := el.Call("getBoundingClientRect")
+			dx := before.Get("left").Float() - after.Get("left").Float()
+			dy := before.Get("top").Float() - after.Get("top").Float()
+			if dx == 0 && dy == 0 {
+				continue
+			}
+			style := el.Get("style")
+			style.Set("transform", "translate("+strconv.FormatFloat(dx, 'f', -1, 64)+"px, "+strconv.FormatFloat(dy, 'f', -1, 64)+"px)")
+			style.Set("transitionDuration", "0s")
+			moved = append(moved, el)
+		}
+		if len(moved) == 0 {
+			return
+		}
+		// Reading the layout applies the inverted positions before they transition.
+		js.Global().Get("document").Get("body").Get("offsetHeight")
+		for _, el := range moved {
+			el.Get("classList").Call("add", t.name+"-move")
+			style := el.Get("style")
+			style.Set("transform", "")
+			style.Set("transitionDuration", "")
+			el := el
+			setTimeout(transitionDuration(el), func() {
+				el.Get("classList").Call("remove", t.name+"-move")
+			})
+		}
+	})
+}
+
+// enter transitions the created child into the element.
+func (t *transition) enter(child *vnode) {
+	if t == nil || child.typ != html.ElementNode {
+		return
+	}
+	child.patches.do(func() {
+		t.animate(child.node.Underlying(), "enter", func(el js.Value) {
+			el.Get("classList").Call("remove", t.name+"-enter-active", t.name+"-enter-to")
+		})
+	})
+}
+
+// leave transitions the removed child out of the element, then removes its dom node.
+func (t *transition) leave(child *vnode) {
+	t.animate(child.node.Underlying(), "leave", func(el js.Value) {
+		el.Call("remove")
+	})
+}
+
+// animate applies the classes of the phase then calls done once the transition ends.
+// The start class is replaced by the to class on the next frame, which starts the transition.
+func (t *transition) animate(el js.Value, phase string, done func(js.Value)) {
+	class := t.name + "-" + phase
+	el.Get("classList").Call("add", class, class+"-active")
+	requestAnimationFrame(func() {
+		el.Get("classList").Call("remove", class)
+		el.Get("classList").Call("add", class+"-to")
+		setTimeout(transitionDuration(el), func() {
+			done(el)
+		})
+	})
+}
+
+// transitionDuration returns the longest duration of the transitions and animations of the element, including delays.
+func transitionDuration(el js.Value) time.Duration {
+	style := js.Global().Call("getComputedStyle", el)
+	transition := maxDuration(style.Get("transitionDuration").String()) + maxDuration(style.Get("transitionDelay").String())
+	animation := maxDuration(style.Get("animationDuration").String()) + maxDuration(style.Get("animationDelay").String())
+	if animation > transition {
+		return animation
+	}
+	return transition
+}
+
+// maxDuration returns the longest of the css durations, e.g. 0.3s, 100ms.
+func maxDuration(durations string) time.Duration {
+	var max time.Duration
+	for _, css := range strings.Split(durations, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(css))
+		if err == nil && d > max {
+			max = d
+		}
+	}
+	return max
+}
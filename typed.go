@@ -0,0 +1,77 @@
+package vue
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Typed methods and computed receive the data of the component by its type, e.g. func Add(context vue.Context, data *Data),
+// so they do not assert the type of context.Data(). Types are checked by reflection instead of generics,
+// since the tree builds with the syscall/js api of Go 1.11 which predates generics:
+// when the option is applied after the data option, e.g. by NewTyped, and when called.
+
+// NewTyped creates a new view model with the data from the given options,
+// which check their typed methods and computed against the type of the data.
+func NewTyped(data interface{}, options ...Option) *ViewModel {
+	return New(append([]Option{Data(data)}, options...)...)
+}
+
+// TypedMethods is the methods option for components of functions of the signature func(vue.Context, *Data),
+// which are registered by function name. Panics if a function is not of the signature or the data is not of the type.
+func TypedMethods(functions ...interface{}) Option {
+	return func(comp *Comp) {
+		for _, function := range functions {
+			fn := comp.typedFunc(function, 0)
+			comp.methods[funcName(function)] = func(context Context) {
+				fn.Call(typedArgs(context, fn))
+			}
+		}
+	}
+}
+
+// TypedComputed is the computed option for components of functions of the signature func(vue.Context, *Data) interface{},
+// which are registered by function name. Panics if a function is not of the signature or the data is not of the type.
+func TypedComputed(functions ...interface{}) Option {
+	return func(comp *Comp) {
+		for _, function := range functions {
+			fn := comp.typedFunc(function, 1)
+			comp.computed[funcName(function)] = func(context Context) interface{} {
+				return fn.Call(typedArgs(context, fn))[0].Interface()
+			}
+		}
+	}
+}
+
+// typedFunc checks the typed function of the number of results against the data of the component, unless not given yet.
+func (comp *Comp) typedFunc(function interface{}, results int) reflect.Value {
+	fn := reflect.ValueOf(function)
+	typ := fn.Type()
+	if typ.Kind() != reflect.Func || typ.NumIn() != 2 || typ.In(0) != contextType || typ.NumOut() != results ||
+		(results == 1 && typ.Out(0) != interfaceType) {
+		must(fmt.Errorf("typed function is not of the signature func(vue.Context, *Data)%s: %T", typedResult[results], function))
+	}
+	if _, untyped := comp.data.(struct{}); !untyped {
+		checkTyped(typ, comp.data)
+	}
+	return fn
+}
+
+// typedArgs returns the arguments of the typed function, the context and its data.
+func typedArgs(context Context, fn reflect.Value) []reflect.Value {
+	data := context.Data()
+	checkTyped(fn.Type(), data)
+	return []reflect.Value{reflect.ValueOf(&context).Elem(), reflect.ValueOf(data)}
+}
+
+// checkTyped checks the data is of the type of the data argument of the typed function.
+func checkTyped(typ reflect.Type, data interface{}) {
+	if dataType := reflect.TypeOf(data); dataType != typ.In(1) {
+		must(fmt.Errorf("data of typed function is not of type %s: %s", typ.In(1), dataType))
+	}
+}
+
+var (
+	contextType   = reflect.TypeOf((*Context)(nil)).Elem()
+	interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+	typedResult   = []string{"", " interface{}"}
+)
@@ -0,0 +1,51 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vue
+
+import (
+	"testing"
+)
+
+func Add(context Context, data *counter) {
+	data.Count++
+}
+
+func Double(context Context, data *counter) interface{} {
+	return data.Count * 2
+}
+
+func TestTyped(t *testing.T) {
+	comp := Component(
+		Template(`<p>{{ Double }}</p>`),
+		Data(&counter{}),
+		TypedMethods(Add),
+		TypedComputed(Double),
+	)
+	vm := Headless(comp, nil)
+	vm.Call("Add")
+	if got, want := vm.HTML(), "<p>2</p>"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestTypedInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+	}{
+		{"signature", []Option{TypedMethods(func(Context) {})}},
+		{"result", []Option{TypedComputed(Add)}},
+		{"data", []Option{Data(&struct{}{}), TypedMethods(Add)}},
+	}
+	for _, test := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: did not panic", test.name)
+				}
+			}()
+			Component(test.options...)
+		}()
+	}
+}
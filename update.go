@@ -0,0 +1,44 @@
+package vue
+
+// Update queues the function to mutate the data of the component on the render loop, then renders.
+// Update is safe to call from any goroutine, e.g. WebSocket readers and timers.
+func (vm *ViewModel) Update(fn func(data interface{})) {
+	comp := vm.comp
+	comp.callback.update(func() {
+		fn(comp.data)
+	})
+}
+
+// Go runs the function in a goroutine, e.g. to fetch then update data.
+// The component renders once the function returns and errors are reported by the root component.
+func (vm *ViewModel) Go(fn func() error) {
+	comp := vm.comp
+	go func() {
+		err := fn()
+		comp.callback.update(func() {
+			if err != nil {
+				comp.callback.reportErr(err)
+			}
+		})
+	}()
+}
+
+// update queues the mutation to be applied before the next render of the root view model.
+func (vm *ViewModel) update(mutation func()) {
+	vm.mu.Lock()
+	vm.updates = append(vm.updates, mutation)
+	vm.mu.Unlock()
+	vm.render()
+}
+
+// applyUpdates applies the queued mutations.
+func (vm *ViewModel) applyUpdates() {
+	vm.mu.Lock()
+	updates := vm.updates
+	vm.updates = nil
+	vm.mu.Unlock()
+
+	for _, mutation := range updates {
+		mutation()
+	}
+}
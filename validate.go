@@ -0,0 +1,255 @@
+package vue
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TemplateErrors are the errors found by validating a template.
+type TemplateErrors []*TemplateError
+
+// Error returns the errors on separate lines.
+func (errs TemplateErrors) Error() string {
+	lines := make([]string, len(errs))
+	for i, err := range errs {
+		lines[i] = err.Error()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mustacheTag matches the tags of interpolations, e.g. {{ Todo.Text }} or {{#Done}}.
+var mustacheTag = regexp.MustCompile(`\{\{\{?\s*([#^/!&>]?)\s*([^}]*?)\s*\}?\}\}`)
+
+// scope are the types of the names in scope of a template.
+// A nil type is a name of unknown type, e.g. of an interface.
+type scope map[string]reflect.Type
+
+// Validate validates the template against the fields and methods of the type of the data,
+// e.g. to catch typos by tests without running in a browser.
+// Directives and interpolations must refer to fields, loop variables or methods of the data,
+// where methods have the signature of methods or computed, e.g. func (data *Data) Add(vue.Context).
+// Dotted paths are resolved through nested structs, pointers and maps.
+// Returns TemplateErrors with every error found, or an error without data.
+func Validate(tmpl string, data interface{}) error {
+	if data == nil {
+		return fmt.Errorf("data of the template is nil")
+	}
+	typ := reflect.TypeOf(data)
+	s := make(scope)
+	for i := 0; i < typ.NumMethod(); i++ {
+		s[typ.Method(i).Name] = nil
+	}
+	if elem := indirectType(typ); elem.Kind() == reflect.Struct {
+		s.fields(elem)
+	}
+
+	vd := &validator{tmpl: tmpl}
+	root := parseNode(tmpl)
+	for child := root.FirstChild; child != nil; child = child.NextSibling {
+		vd.node(child, s)
+	}
+	if len(vd.errs) > 0 {
+		return vd.errs
+	}
+	return nil
+}
+
+// validator collects the errors of validating a template.
+type validator struct {
+	tmpl string
+	errs TemplateErrors
+}
+
+// node recursively validates the node within the scope.
+func (vd *validator) node(node *html.Node, s scope) {
+	switch node.Type {
+	case html.TextNode:
+		for _, match := range mustacheTag.FindAllStringSubmatch(node.Data, -1) {
+			if translation := translateTag.FindStringSubmatch(match[0]); translation != nil {
+				for _, param := range translateParam.FindAllStringSubmatch(translation[3], -1) {
+					if _, err := strconv.ParseFloat(param[2], 64); err != nil && !strings.HasPrefix(param[2], `"`) {
+						vd.path(node, "", match[0], param[2], s)
+					}
+				}
+				continue
+			}
+			if match[1] == "" || match[1] == "#" || match[1] == "^" || match[1] == "&" {
+				vd.path(node, "", match[0], match[2], s)
+			}
+		}
+	case html.ElementNode:
+		for _, attr := range node.Attr {
+			if attr.Key == vFor {
+				s = vd.loop(node, attr, s)
+			}
+		}
+		for _, attr := range node.Attr {
+			vd.attr(node, attr, s)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			vd.node(child, s)
+		}
+	}
+}
+
+// fields adds the exported fields of the struct type to the scope.
+// Fields of embedded structs are promoted unless shadowed.
+func (s scope) fields(typ reflect.Type) {
+	var embedded []reflect.Type
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath == "" {
+			if _, ok := s[fieldName(field)]; !ok {
+				s[fieldName(field)] = field.Type
+			}
+		}
+		if elem := indirectType(field.Type); field.Anonymous && elem != nil && elem.Kind() == reflect.Struct {
+			embedded = append(embedded, elem)
+		}
+	}
+	for _, elem := range embedded {
+		s.fields(elem)
+	}
+}
+
+// loop validates the for attribute and returns the scope with the loop variable.
+func (vd *validator) loop(node *html.Node, attr html.Attribute, s scope) scope {
+	vals := strings.SplitN(attr.Val, " in ", 2)
+	if len(vals) != 2 {
+		vd.add(node, attr, fmt.Errorf("invalid for expression: %s", attr.Val))
+		return s
+	}
+	name, field := strings.TrimSpace(vals[0]), strings.TrimSpace(vals[1])
+	typ, err := s.resolve(field)
+	if err != nil {
+		vd.add(node, attr, err)
+		return s
+	}
+
+	loop := make(scope, len(s)+1)
+	for key, value := range s {
+		loop[key] = value
+	}
+	loop[name] = nil
+	if typ = indirectType(typ); typ != nil && (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) {
+		loop[name] = typ.Elem()
+	}
+	return loop
+}
+
+// attr validates the vue attribute within the scope.
+func (vd *validator) attr(node *html.Node, attr html.Attribute, s scope) {
+	typ := strings.Split(attr.Key, ":")[0]
+	switch typ {
+	case vBind, vModel, vHtml, vOn:
+		if _, err := s.resolve(attr.Val); err != nil {
+			vd.add(node, attr, err)
+		}
+	case vIf:
+		if _, ok := flagName(attr.Val); !ok {
+			if _, err := s.resolve(attr.Val); err != nil {
+				vd.add(node, attr, err)
+			}
+		}
+	case vFor, vTrack, vFullscreen, vAsset:
+	default:
+		if _, ok := directives[strings.TrimPrefix(typ, v)]; ok && strings.HasPrefix(typ, v) {
+			if attr.Val != "" {
+				if _, err := s.resolve(attr.Val); err != nil {
+					vd.add(node, attr, err)
+				}
+			}
+			return
+		}
+		if strings.HasPrefix(attr.Key, v) {
+			vd.add(node, attr, fmt.Errorf("unknown vue attribute: %s", typ))
+		}
+	}
+}
+
+// path validates the dotted path of the interpolation within the scope.
+func (vd *validator) path(node *html.Node, key, snippet, path string, s scope) {
+	if path == "." {
+		return
+	}
+	if _, err := s.resolve(path); err != nil {
+		vd.errorf(node, key, snippet, snippet, "%v", err)
+	}
+}
+
+// resolve resolves the type of the dotted path within the scope.
+// Paths through maps and unknown types resolve to nil.
+func (s scope) resolve(path string) (reflect.Type, error) {
+	names := strings.Split(path, ".")
+	typ, ok := s[names[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown data field: %s", names[0])
+	}
+	for _, name := range names[1:] {
+		typ = indirectType(typ)
+		if typ == nil || typ.Kind() != reflect.Struct {
+			return nil, nil
+		}
+		field, ok := structField(typ, name)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %s of type %s", name, typ)
+		}
+		typ = field.Type
+	}
+	return typ, nil
+}
+
+// add adds the error of the vue attribute.
+func (vd *validator) add(node *html.Node, attr html.Attribute, err error) {
+	source := fmt.Sprintf("%s=%q", attr.Key, attr.Val)
+	vd.errorf(node, attr.Key, fmt.Sprintf("<%s %s>", node.Data, source), source, "%v", err)
+}
+
+// errorf adds the formatted error of the snippet located by the source.
+func (vd *validator) errorf(node *html.Node, key, snippet, source, format string, args ...interface{}) {
+	path := nodePath(node)
+	if key != "" {
+		path = fmt.Sprintf("%s[%s]", path, key)
+	}
+	line, column := position(vd.tmpl, source)
+	vd.errs = append(vd.errs, &TemplateError{Component: "root", Snippet: snippet, Path: path,
+		Line: line, Column: column, Err: fmt.Errorf(format, args...)})
+}
+
+// structField returns the exported field of the struct type by the vue tag.
+// Fields of embedded structs are promoted unless shadowed.
+func structField(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		if field := typ.Field(i); field.PkgPath == "" && fieldName(field) == name {
+			return field, true
+		}
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		embedded := typ.Field(i)
+		elem := indirectType(embedded.Type)
+		if !embedded.Anonymous || elem == nil || elem.Kind() != reflect.Struct {
+			continue
+		}
+		if field, ok := structField(elem, name); ok {
+			field.Index = append([]int{embedded.Index[0]}, field.Index...)
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// indirectType returns the type which pointers point to.
+// Returns nil for unknown types, i.e. nil and interfaces.
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ != nil && typ.Kind() == reflect.Interface {
+		return nil
+	}
+	return typ
+}
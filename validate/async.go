@@ -0,0 +1,96 @@
+package validate
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Async validates values asynchronously, e.g. the availability of a username by fetch.
+// Validations are debounced and canceled when a newer value is validated.
+type Async struct {
+	validate func(ctx context.Context, value interface{}) error
+	delay    time.Duration
+	done     func()
+
+	mu      sync.Mutex
+	pending bool
+	err     error
+	cancel  context.CancelFunc
+	timer   *time.Timer
+}
+
+// NewAsync creates a new async validator with the debounce delay.
+// The validate function should return early once its context is canceled.
+// The done function is called when a validation settles, e.g. to render.
+func NewAsync(validate func(ctx context.Context, value interface{}) error, delay time.Duration, done func()) *Async {
+	return &Async{validate: validate, delay: delay, done: done}
+}
+
+// Validate validates the value after the debounce delay.
+// Pending validations of previous values are canceled.
+func (a *Async) Validate(value interface{}) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.pending = true
+	a.err = nil
+	a.cancel = cancel
+	a.timer = time.AfterFunc(a.delay, func() {
+		err := a.validate(ctx, value)
+		a.mu.Lock()
+		if ctx.Err() != nil {
+			a.mu.Unlock()
+			return
+		}
+		a.pending = false
+		a.err = err
+		a.mu.Unlock()
+		cancel()
+		if a.done != nil {
+			a.done()
+		}
+	})
+}
+
+// Cancel cancels the pending validation.
+func (a *Async) Cancel() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stop()
+	a.pending = false
+}
+
+// Pending determines if a validation is pending.
+func (a *Async) Pending() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.pending
+}
+
+// Err returns the error of the settled validation.
+// Returns nil while pending.
+func (a *Async) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// AddTo adds the error of the settled validation to the errors of the field.
+func (a *Async) AddTo(errs Errors, field string) {
+	if err := a.Err(); err != nil {
+		errs.Add(field, err.Error())
+	}
+}
+
+// stop stops the debounce timer and cancels the context of the pending validation.
+func (a *Async) stop() {
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+	if a.cancel != nil {
+		a.cancel()
+	}
+}
@@ -0,0 +1,308 @@
+// Package validate validates data by rules derived from struct tags.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// tagName is the struct tag of validation rules, e.g. `validate:"required,min=3,email"`.
+const tagName = "validate"
+
+// emailPattern is a permissive pattern of email addresses.
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// Errors are validation errors by field.
+// Fields are named by the vue tag like in templates, otherwise by the field name.
+type Errors map[string][]string
+
+// Valid determines if there are no errors.
+func (errs Errors) Valid() bool {
+	return len(errs) == 0
+}
+
+// First returns the first error of the field.
+// Returns an empty string for valid fields.
+func (errs Errors) First(field string) string {
+	if len(errs[field]) == 0 {
+		return ""
+	}
+	return errs[field][0]
+}
+
+// Form is the field of errors which are attached to the form instead of a field.
+const Form = ""
+
+// Add adds the error to the field, or to the form by the form field.
+func (errs Errors) Add(field, err string) {
+	errs[field] = append(errs[field], err)
+}
+
+// Check is a cross-field check which adds errors to fields or the form,
+// e.g. a date range where the start must be before the end.
+type Check func(data interface{}, errs Errors)
+
+// rule is a validation rule which returns an error message for invalid values.
+// Cross-field rules refer to fields of the parent struct by the parameter.
+// Errors are returned for invalid rules, e.g. an unknown field or a parameter which is not a number.
+type rule func(value reflect.Value, param string, parent reflect.Value) (string, error)
+
+// rules are the validation rules by name.
+var rules = map[string]rule{
+	"required": required,
+	"min":      min,
+	"max":      max,
+	"len":      length,
+	"email":    email,
+	"eqfield":  eqField,
+	"gtfield":  gtField,
+	"ltfield":  ltField,
+}
+
+// Struct validates the fields of the struct by the rules of their validate tags, then by the checks.
+// Rules are separated by commas and parameters follow an equals sign.
+// The rules are required, min=n, max=n, len=n and email,
+// where sizes are lengths of strings in characters, of slices and maps, and values of numbers.
+// Empty strings, slices and maps are missing for the required rule, like zero values.
+// The cross-field rules are eqfield=F, gtfield=F and ltfield=F which compare to the field F by Go name,
+// where numbers and times are ordered, e.g. a password confirmation by `validate:"eqfield=Password"`.
+// An error is returned for data which is not a struct and for invalid tags, e.g. unknown rules.
+func Struct(data interface{}, checks ...Check) (Errors, error) {
+	errs := make(Errors)
+	value := reflect.Indirect(reflect.ValueOf(data))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("data is not of kind struct: %T", data)
+	}
+
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get(tagName)
+		if field.PkgPath != "" || tag == "" {
+			continue
+		}
+		name := fieldName(field)
+		for _, r := range strings.Split(tag, ",") {
+			key, param := parseRule(r)
+			rule, ok := rules[key]
+			if !ok {
+				return nil, fmt.Errorf("unknown validation rule of field %s: %s", field.Name, key)
+			}
+			msg, err := rule(value.Field(i), param, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid validation rule of field %s: %s: %v", field.Name, key, err)
+			}
+			if msg != "" {
+				errs.Add(name, msg)
+			}
+		}
+	}
+
+	for _, check := range checks {
+		check(data, errs)
+	}
+	return errs, nil
+}
+
+// parseRule parses the rule into its name and parameter.
+func parseRule(r string) (string, string) {
+	parts := strings.SplitN(strings.TrimSpace(r), "=", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// fieldName returns the name of the struct field by the vue tag.
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("vue"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// required requires the value to not be the zero value or empty.
+func required(value reflect.Value, _ string, _ reflect.Value) (string, error) {
+	if isZero(value) {
+		return "is required", nil
+	}
+	return "", nil
+}
+
+// min requires the size of the value to be at least the parameter.
+func min(value reflect.Value, param string, _ reflect.Value) (string, error) {
+	return compareParam(value, param, func(size, n float64) bool {
+		return size >= n
+	}, "must be at least %s")
+}
+
+// max requires the size of the value to be at most the parameter.
+func max(value reflect.Value, param string, _ reflect.Value) (string, error) {
+	return compareParam(value, param, func(size, n float64) bool {
+		return size <= n
+	}, "must be at most %s")
+}
+
+// length requires the size of the value to be the parameter.
+func length(value reflect.Value, param string, _ reflect.Value) (string, error) {
+	return compareParam(value, param, func(size, n float64) bool {
+		return size == n
+	}, "must have a length of %s")
+}
+
+// compareParam compares the size of the value to the number of the parameter,
+// then returns the message formatted by the parameter unless valid.
+func compareParam(value reflect.Value, param string, valid func(size, n float64) bool, format string) (string, error) {
+	n, err := parseFloat(param)
+	if err != nil {
+		return "", err
+	}
+	s, err := size(value)
+	if err != nil {
+		return "", err
+	}
+	if !valid(s, n) {
+		return fmt.Sprintf(format, param), nil
+	}
+	return "", nil
+}
+
+// email requires the value to be an email address unless empty.
+func email(value reflect.Value, _ string, _ reflect.Value) (string, error) {
+	if value.Kind() != reflect.String {
+		return "", fmt.Errorf("email rule requires kind string: %s", value.Kind())
+	}
+	if s := value.String(); s != "" && !emailPattern.MatchString(s) {
+		return "must be an email address", nil
+	}
+	return "", nil
+}
+
+// eqField requires the value to equal the value of the other field.
+func eqField(value reflect.Value, param string, parent reflect.Value) (string, error) {
+	other, err := otherField(parent, param)
+	if err != nil {
+		return "", err
+	}
+	if !reflect.DeepEqual(value.Interface(), other.Interface()) {
+		return fmt.Sprintf("must match %s", param), nil
+	}
+	return "", nil
+}
+
+// gtField requires the value to be greater than the value of the other field.
+func gtField(value reflect.Value, param string, parent reflect.Value) (string, error) {
+	c, err := compareField(value, param, parent)
+	if err != nil || c > 0 {
+		return "", err
+	}
+	return fmt.Sprintf("must be after %s", param), nil
+}
+
+// ltField requires the value to be less than the value of the other field.
+func ltField(value reflect.Value, param string, parent reflect.Value) (string, error) {
+	c, err := compareField(value, param, parent)
+	if err != nil || c < 0 {
+		return "", err
+	}
+	return fmt.Sprintf("must be before %s", param), nil
+}
+
+// compareField compares the value to the value of the other field of the parent struct by Go name.
+func compareField(value reflect.Value, name string, parent reflect.Value) (int, error) {
+	other, err := otherField(parent, name)
+	if err != nil {
+		return 0, err
+	}
+	return compare(value, other)
+}
+
+// otherField returns the field of the parent struct by Go name.
+func otherField(parent reflect.Value, name string) (reflect.Value, error) {
+	field := parent.FieldByName(name)
+	if !field.IsValid() {
+		return reflect.Value{}, fmt.Errorf("unknown field: %s", name)
+	}
+	return field, nil
+}
+
+// compare compares times or the sizes of values.
+// Returns a negative number if a is less than b, zero if equal, otherwise a positive number.
+func compare(a, b reflect.Value) (int, error) {
+	if at, ok := a.Interface().(time.Time); ok {
+		bt, ok := b.Interface().(time.Time)
+		if !ok {
+			return 0, fmt.Errorf("time is not comparable to type: %s", b.Type())
+		}
+		switch {
+		case at.Before(bt):
+			return -1, nil
+		case at.After(bt):
+			return 1, nil
+		default:
+			return 0, nil
+		}
+	}
+
+	as, err := size(a)
+	if err != nil {
+		return 0, err
+	}
+	bs, err := size(b)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case as < bs:
+		return -1, nil
+	case as > bs:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// size returns the number of characters of strings, the length of slices and maps or the value of numbers.
+func size(value reflect.Value) (float64, error) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), nil
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(value.Len()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), nil
+	default:
+		return 0, fmt.Errorf("size is unknown for kind: %s", value.Kind())
+	}
+}
+
+// isZero determines if the value is the zero value of its type, or an empty string, slice or map.
+func isZero(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	default:
+		return reflect.DeepEqual(value.Interface(), reflect.Zero(value.Type()).Interface())
+	}
+}
+
+// parseFloat parses the parameter of a rule.
+func parseFloat(param string) (float64, error) {
+	f, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rule parameter: %s", param)
+	}
+	return f, nil
+}
@@ -0,0 +1,125 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestStruct(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		data interface{}
+		want Errors
+	}{
+		{"required", &struct {
+			Name string `validate:"required"`
+		}{}, Errors{"Name": {"is required"}}},
+		{"required slice", &struct {
+			Tags []string `validate:"required"`
+		}{Tags: []string{}}, Errors{"Tags": {"is required"}}},
+		{"required pointer", &struct {
+			Next *int `validate:"required"`
+		}{}, Errors{"Next": {"is required"}}},
+		{"required valid", &struct {
+			Age int `validate:"required"`
+		}{Age: 1}, Errors{}},
+		{"min characters", &struct {
+			Name string `validate:"min=3"`
+		}{Name: "éé"}, Errors{"Name": {"must be at least 3"}}},
+		{"min valid characters", &struct {
+			Name string `validate:"min=3"`
+		}{Name: "ééé"}, Errors{}},
+		{"max number", &struct {
+			Age int `validate:"max=120"`
+		}{Age: 121}, Errors{"Age": {"must be at most 120"}}},
+		{"len slice", &struct {
+			Pin []int `validate:"len=4"`
+		}{Pin: []int{1, 2, 3}}, Errors{"Pin": {"must have a length of 4"}}},
+		{"email", &struct {
+			Email string `validate:"email"`
+		}{Email: "milk"}, Errors{"Email": {"must be an email address"}}},
+		{"email empty", &struct {
+			Email string `validate:"email"`
+		}{}, Errors{}},
+		{"named by vue tag", &struct {
+			Email string `vue:"email" validate:"required,email"`
+		}{}, Errors{"email": {"is required"}}},
+		{"multiple errors", &struct {
+			Name string `validate:"required,min=2"`
+		}{}, Errors{"Name": {"is required", "must be at least 2"}}},
+		{"eqfield", &struct {
+			Password string
+			Confirm  string `validate:"eqfield=Password"`
+		}{Password: "secret", Confirm: "secrets"}, Errors{"Confirm": {"must match Password"}}},
+		{"gtfield time", &struct {
+			Start time.Time
+			End   time.Time `validate:"gtfield=Start"`
+		}{Start: now, End: now}, Errors{"End": {"must be after Start"}}},
+		{"ltfield number", &struct {
+			Min int `validate:"ltfield=Max"`
+			Max int
+		}{Min: 1, Max: 2}, Errors{}},
+		{"unexported", &struct {
+			name string `validate:"required"`
+		}{}, Errors{}},
+	}
+	for _, test := range tests {
+		got, err := Struct(test.data)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestStructInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{}
+	}{
+		{"not a struct", "milk"},
+		{"unknown rule", &struct {
+			Name string `validate:"unknown"`
+		}{}},
+		{"parameter not a number", &struct {
+			Name string `validate:"min=three"`
+		}{}},
+		{"unknown field", &struct {
+			Confirm string `validate:"eqfield=Password"`
+		}{}},
+		{"size of struct", &struct {
+			At struct{} `validate:"min=1"`
+		}{}},
+		{"email of number", &struct {
+			Email int `validate:"email"`
+		}{}},
+	}
+	for _, test := range tests {
+		if _, err := Struct(test.data); err == nil {
+			t.Errorf("%s: no error", test.name)
+		}
+	}
+}
+
+func TestStructChecks(t *testing.T) {
+	type form struct {
+		Start, End int
+	}
+	check := func(data interface{}, errs Errors) {
+		if f := data.(*form); f.Start > f.End {
+			errs.Add(Form, "start must precede end")
+		}
+	}
+	errs, err := Struct(&form{Start: 2, End: 1}, check)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs.Valid() || errs.First(Form) != "start must precede end" {
+		t.Errorf("got %v, want a form error", errs)
+	}
+}
@@ -0,0 +1,30 @@
+package vue
+
+// visibleField is the data field of the page visibility state.
+const visibleField = "Visible"
+
+// visibility is the page visibility state of a component.
+type visibility struct {
+	onHidden  func(Context)
+	onVisible func(Context)
+}
+
+// Visibility is the page visibility option for root components.
+// The visibility of the page is mapped to the Visible data field unless data has the same field.
+// The transition functions are called when the page is hidden or visible, either may be nil,
+// e.g. to pause polling or animations while the tab is in the background.
+func Visibility(onHidden, onVisible func(Context)) Option {
+	return func(comp *Comp) {
+		comp.visibility = &visibility{onHidden: onHidden, onVisible: onVisible}
+	}
+}
+
+// visibilityData maps the page visibility state to data.
+func (vm *ViewModel) visibilityData() {
+	if vm.comp.visibility == nil {
+		return
+	}
+	if _, ok := vm.data[visibleField]; !ok {
+		vm.data[visibleField] = isVisible()
+	}
+}
@@ -0,0 +1,37 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"github.com/gowasm/go-js-dom"
+)
+
+// watchVisibility watches the visibility of the page.
+func (vm *ViewModel) watchVisibility() {
+	visibility := vm.comp.visibility
+	if visibility == nil || vm.comp.isSub {
+		return
+	}
+
+	vm.listen(document, "visibilitychange", func(dom.Event) {
+		defer vm.report()
+		if isVisible() {
+			vm.breadcrumb("visible")
+			if visibility.onVisible != nil {
+				visibility.onVisible(vm)
+			}
+		} else {
+			vm.breadcrumb("hidden")
+			if visibility.onHidden != nil {
+				visibility.onHidden(vm)
+			}
+		}
+		vm.render()
+	})
+}
+
+// isVisible determines if the page is visible.
+func isVisible() bool {
+	return document.Underlying().Get("visibilityState").String() == "visible"
+}
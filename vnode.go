@@ -1,3 +1,6 @@
+//go:build js && wasm
+// +build js,wasm
+
 package vue
 
 import (
@@ -10,6 +13,10 @@ import (
 
 var document dom.Document
 
+// commentNode is the node type of dom comments, which are left unmanaged by the virtual dom,
+// e.g. the comments of markup rendered by the server.
+const commentNode = 8
+
 type vnode struct {
 	parent, firstChild, lastChild, prevSibling, nextSibling *vnode
 
@@ -17,13 +24,17 @@ type vnode struct {
 	typ   html.NodeType
 	data  string
 
-	node dom.Node
+	node    dom.Node
+	patches *patches
+	group   *transition
 }
 
+// init wraps the document of the browser.
+// The document is nil outside of browsers, e.g. tests which validate templates by node.
 func init() {
 	doc := js.Global().Get("document")
 	if doc == js.Undefined() || doc == js.Null() {
-		panic("failed to initialize document")
+		return
 	}
 	document = dom.WrapDocument(doc)
 }
@@ -43,10 +54,13 @@ func newNode(node dom.Node) *vnode {
 		vnode.attrs = make(map[string]string, len(attrs))
 		for key, val := range attrs {
 			vnode.attrs[key] = val
+			listenModifiers(n.Underlying(), key, "", val)
 		}
 
 		for child := node.FirstChild(); child != nil; child = child.NextSibling() {
-			vnode.append(newNode(child))
+			if child.NodeType() != commentNode {
+				vnode.append(newNode(child))
+			}
 		}
 	case dom.Text:
 		vnode.typ = html.TextNode
@@ -60,22 +74,39 @@ func newNode(node dom.Node) *vnode {
 }
 
 // render recursively renders the virtual node.
+// Keyed children are moved into place which preserves their dom nodes, e.g. focus and input state.
+// Children of transition groups are animated as they enter, leave and move.
 func (dst *vnode) render(src *html.Node) {
+	dst.group = dst.transition()
+	defer dst.group.move(dst)
+	keyed := dst.keyed()
 	for dstChild, srcChild := dst.firstChild, src.FirstChild; dstChild != nil || srcChild != nil; {
+		if srcChild != nil {
+			if match, ok := keyed[nodeKey(srcChild)]; ok && match != dstChild {
+				dst.insertBefore(match, dstChild)
+				dstChild = match
+			}
+		}
+		if dstChild != nil {
+			delete(keyed, dstChild.attrs[key])
+		}
+
 		switch {
 		case dstChild == nil:
-			dst.append(createNode(srcChild))
+			child := dst.createNode(srcChild)
+			dst.append(child)
+			dst.group.enter(child)
 		case srcChild == nil:
 			dst.remove(dstChild)
 		case dstChild.typ != srcChild.Type:
-			dst.replace(createNode(srcChild), dstChild)
+			dst.replace(dst.createNode(srcChild), dstChild)
 		default:
 			switch srcChild.Type {
 			case html.ElementNode:
 				if dstChild.data != srcChild.Data {
-					dst.replace(createNode(srcChild), dstChild)
+					dst.replace(dst.createNode(srcChild), dstChild)
 				} else {
-					dstChild.renderAttributes(dstChild.attrs)
+					dstChild.renderAttributes(attrMap(srcChild))
 					dstChild.render(srcChild)
 				}
 			case html.TextNode:
@@ -95,22 +126,25 @@ func (dst *vnode) render(src *html.Node) {
 	}
 }
 
-// createNode recursively creates a virtual node from the html node.
-// createNode recursively creates a virtual node from the html node.
-func createNode(node *html.Node) *vnode {
-	vnode := &vnode{typ: node.Type, data: node.Data}
+// createNode recursively creates a virtual node from the html node within the tree of the node.
+func (dst *vnode) createNode(node *html.Node) *vnode {
+	vnode := &vnode{typ: node.Type, data: node.Data, patches: dst.patches}
 	switch node.Type {
 	case html.ElementNode:
-		vnode.node = document.CreateElement(node.Data)
+		vnode.patches.do(func() {
+			vnode.node = document.CreateElement(node.Data)
+		})
 		vnode.attrs = make(map[string]string, len(node.Attr))
 		for _, attr := range node.Attr {
 			vnode.setAttr(attr.Key, attr.Val)
 		}
 		for child := node.FirstChild; child != nil; child = child.NextSibling {
-			vnode.append(createNode(child))
+			vnode.append(vnode.createNode(child))
 		}
 	case html.TextNode:
-		vnode.node = document.CreateTextNode(node.Data)
+		vnode.patches.do(func() {
+			vnode.node = document.CreateTextNode(node.Data)
+		})
 	default:
 		must(fmt.Errorf("unknown node type: %v", node.Type))
 	}
@@ -139,27 +173,54 @@ func (vnode *vnode) renderAttributes(attrs map[string]string) {
 }
 
 // setAttr sets an attribute of the element.
+// The value and checked properties are set with their attributes which reflect the current state of inputs.
 func (vnode *vnode) setAttr(key, val string) {
+	old := vnode.attrs[key]
 	vnode.attrs[key] = val
-	if vnode.node != nil {
-		vnode.node.(dom.Element).SetAttribute(key, val)
-	}
+	vnode.patches.do(func() {
+		if vnode.node != nil {
+			vnode.node.(dom.Element).SetAttribute(key, val)
+			listenModifiers(vnode.node.Underlying(), key, old, val)
+			switch key {
+			case "value":
+				vnode.node.Underlying().Set(key, val)
+			case "checked":
+				vnode.node.Underlying().Set(key, true)
+			}
+		}
+	})
 }
 
 // remAttr removes an attribute from the element.
 func (vnode *vnode) remAttr(key string) {
+	old := vnode.attrs[key]
 	delete(vnode.attrs, key)
-	if vnode.node != nil {
-		vnode.node.(dom.Element).RemoveAttribute(key)
-	}
+	vnode.patches.do(func() {
+		if vnode.node != nil {
+			vnode.node.(dom.Element).RemoveAttribute(key)
+			listenModifiers(vnode.node.Underlying(), key, old, "")
+			if key == "checked" {
+				vnode.node.Underlying().Set(key, false)
+			}
+		}
+	})
 }
 
 // setText sets the content of the text.
+// Appended text is appended to the dom node without replacing the content, e.g. lines of logs.
 func (vnode *vnode) setText(content string) {
+	prev := vnode.data
 	vnode.data = content
-	if vnode.node != nil {
+	vnode.patches.do(func() {
+		if vnode.node == nil {
+			return
+		}
+		if len(content) > len(prev) && strings.HasPrefix(content, prev) {
+			vnode.node.Underlying().Call("appendData", content[len(prev):])
+			return
+		}
 		vnode.node.SetTextContent(content)
-	}
+	})
 }
 
 // append appends the child to the node.
@@ -174,9 +235,11 @@ func (vnode *vnode) append(child *vnode) {
 	child.parent = vnode
 	child.prevSibling = prev
 
-	if vnode.node != nil {
-		vnode.node.AppendChild(child.node)
-	}
+	vnode.patches.do(func() {
+		if vnode.node != nil {
+			vnode.node.AppendChild(child.node)
+		}
+	})
 }
 
 // replace replaces a child with a new child.
@@ -196,13 +259,70 @@ func (vnode *vnode) replace(newChild, oldChild *vnode) {
 	newChild.prevSibling = prev
 	newChild.nextSibling = next
 
-	if vnode.node != nil {
-		vnode.node.ReplaceChild(newChild.node, oldChild.node)
-	}
+	vnode.patches.do(func() {
+		if vnode.node != nil {
+			vnode.node.ReplaceChild(newChild.node, oldChild.node)
+		}
+	})
 }
 
 // remove removes a child from the node.
+// Elements of transition groups leave before their dom node is removed.
 func (vnode *vnode) remove(child *vnode) {
+	group := vnode.group
+	if vnode.firstChild == child {
+		vnode.firstChild = child.nextSibling
+	}
+	if child.nextSibling != nil {
+		child.nextSibling.prevSibling = child.prevSibling
+	}
+	if vnode.lastChild == child {
+		vnode.lastChild = child.prevSibling
+	}
+	if child.prevSibling != nil {
+		child.prevSibling.nextSibling = child.nextSibling
+	}
+
+	vnode.patches.do(func() {
+		if group != nil && child.typ == html.ElementNode {
+			group.leave(child)
+			return
+		}
+		if vnode.node != nil {
+			vnode.node.RemoveChild(child.node)
+		}
+	})
+}
+
+// insertBefore moves the child before the reference child of the node.
+// The child is appended for a nil reference child.
+func (vnode *vnode) insertBefore(child, ref *vnode) {
+	vnode.unlink(child)
+	if ref == nil {
+		vnode.append(child)
+		return
+	}
+
+	prev := ref.prevSibling
+	if prev == nil {
+		vnode.firstChild = child
+	} else {
+		prev.nextSibling = child
+	}
+	ref.prevSibling = child
+	child.parent = vnode
+	child.prevSibling = prev
+	child.nextSibling = ref
+
+	vnode.patches.do(func() {
+		if vnode.node != nil {
+			vnode.node.InsertBefore(child.node, ref.node)
+		}
+	})
+}
+
+// unlink unlinks a child from the siblings of the node without removing its dom node.
+func (vnode *vnode) unlink(child *vnode) {
 	if vnode.firstChild == child {
 		vnode.firstChild = child.nextSibling
 	}
@@ -215,8 +335,27 @@ func (vnode *vnode) remove(child *vnode) {
 	if child.prevSibling != nil {
 		child.prevSibling.nextSibling = child.nextSibling
 	}
+	child.prevSibling, child.nextSibling = nil, nil
+}
 
-	if vnode.node != nil {
-		vnode.node.RemoveChild(child.node)
+// keyed maps the keyed element children of the node by key.
+func (dst *vnode) keyed() map[string]*vnode {
+	keyed := make(map[string]*vnode)
+	for child := dst.firstChild; child != nil; child = child.nextSibling {
+		if val, ok := child.attrs[key]; ok && val != "" {
+			keyed[val] = child
+		}
+	}
+	return keyed
+}
+
+// nodeKey returns the key attribute of the html node.
+// Returns an empty key for nodes without a key.
+func nodeKey(node *html.Node) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
 	}
+	return ""
 }
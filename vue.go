@@ -1,34 +1,80 @@
 // Package vue is the progressive framework for wasm applications.
 package vue
 
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
 // ViewModel is a vue view model, e.g. VM.
 type ViewModel struct {
 	comp      *Comp
 	tmpl      *template
-	vnode     *vnode
 	executed  bool
+	scheduled bool
+	renders   int
 	data      map[string]interface{}
-	callbacks map[string]struct{}
+
+	breadcrumbs []string
+	gamepads    []GamepadState
+	ticks       []func()
+
+	mu       sync.Mutex
+	updates  []func()
+	patching bool
+	pending  bool
+	patches  []func()
+	flushed  time.Time
+	profile  Profile
+
+	mounts     map[*Comp]struct{}
+	lifecycle  []func()
+	boundaries map[string]boundary
+	instances  map[instanceKey]*Comp
+	scoped     map[string]*Comp
+	unmounted  bool
+
+	// The state of the view model in the browser, otherwise of headless renders.
+	browser
 }
 
 // New creates a new view model from the given options.
+// The startup of the view model is profiled.
 func New(options ...Option) *ViewModel {
+	start := time.Now()
 	comp := Component(options...)
-	return newViewModel(comp)
+	optionsTime := time.Since(start)
+
+	vm := newViewModel(comp)
+	vm.profile.Options = optionsTime
+	addRoot(vm)
+	return vm
 }
 
 // newViewModel creates a new view model from the given component.
 func newViewModel(comp *Comp) *ViewModel {
-	tmpl := newTemplate(comp)
-	vnode := newNode(comp.el)
-	callbacks := make(map[string]struct{}, 0)
-
-	vm := &ViewModel{comp: comp, tmpl: tmpl, vnode: vnode, callbacks: callbacks}
+	vm := &ViewModel{comp: comp, tmpl: newTemplate(comp)}
+	comp.vm = vm
 	// The root view model satisfies callback which is passed down to subcomponents.
 	if comp.callback == nil {
 		comp.callback = vm
 	}
-	vm.render()
+	if comp.flags != nil && !comp.isSub {
+		comp.flags.subscribe(vm.render)
+	}
+	vm.watch()
+	// The root view model renders immediately when created.
+	if !comp.isSub {
+		start, compile := time.Now(), atomic.LoadInt64(&compileTime)
+		vm.rootMounted()
+		vm.flush()
+		vm.profile.FirstRender = time.Since(start)
+		vm.profile.Compile = time.Duration(atomic.LoadInt64(&compileTime) - compile)
+	}
+	if !comp.isSub {
+		vm.emit(AnalyticsMount, "root")
+	}
 	return vm
 }
 
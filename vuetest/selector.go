@@ -0,0 +1,197 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vuetest
+
+import (
+	"fmt"
+	"golang.org/x/net/html"
+	"strings"
+)
+
+// selector is a compound selector of an element, e.g. button.primary[type="submit"].
+type selector struct {
+	tag     string
+	id      string
+	classes []string
+	attrs   []attrSelector
+}
+
+// attrSelector selects elements by the attribute, optionally of the value.
+type attrSelector struct {
+	key    string
+	val    string
+	hasVal bool
+}
+
+// parseSelector parses the compound selectors combined by descendants, e.g. form .actions button.
+func parseSelector(s string) ([]selector, error) {
+	var sels []selector
+	p := &parser{s: strings.TrimSpace(s)}
+	for p.i < len(p.s) {
+		sel, err := p.compound()
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %s: %v", s, err)
+		}
+		sels = append(sels, sel)
+		p.space()
+	}
+	if len(sels) == 0 {
+		return nil, fmt.Errorf("invalid selector: %q", s)
+	}
+	return sels, nil
+}
+
+// parser parses selectors.
+type parser struct {
+	s string
+	i int
+}
+
+// compound parses a compound selector.
+func (p *parser) compound() (selector, error) {
+	var sel selector
+	if p.i < len(p.s) && p.s[p.i] == '*' {
+		sel.tag = "*"
+		p.i++
+	} else {
+		sel.tag = strings.ToLower(p.ident())
+	}
+	for p.i < len(p.s) && !isSpace(p.s[p.i]) {
+		c := p.s[p.i]
+		p.i++
+		switch c {
+		case '#':
+			sel.id = p.ident()
+			if sel.id == "" {
+				return sel, fmt.Errorf("missing id")
+			}
+		case '.':
+			class := p.ident()
+			if class == "" {
+				return sel, fmt.Errorf("missing class")
+			}
+			sel.classes = append(sel.classes, class)
+		case '[':
+			attr, err := p.attr()
+			if err != nil {
+				return sel, err
+			}
+			sel.attrs = append(sel.attrs, attr)
+		default:
+			return sel, fmt.Errorf("unsupported character: %c", c)
+		}
+	}
+	if sel.tag == "" && sel.id == "" && len(sel.classes) == 0 && len(sel.attrs) == 0 {
+		return sel, fmt.Errorf("empty selector")
+	}
+	return sel, nil
+}
+
+// attr parses the attribute selector after its opening bracket.
+func (p *parser) attr() (attrSelector, error) {
+	attr := attrSelector{key: strings.ToLower(p.ident())}
+	if attr.key == "" {
+		return attr, fmt.Errorf("missing attribute")
+	}
+	if p.i < len(p.s) && p.s[p.i] == '=' {
+		p.i++
+		attr.hasVal = true
+		if p.i < len(p.s) && (p.s[p.i] == '"' || p.s[p.i] == '\'') {
+			quote := p.s[p.i]
+			end := strings.IndexByte(p.s[p.i+1:], quote)
+			if end < 0 {
+				return attr, fmt.Errorf("unterminated attribute value")
+			}
+			attr.val = p.s[p.i+1 : p.i+1+end]
+			p.i += end + 2
+		} else {
+			attr.val = p.ident()
+		}
+	}
+	if p.i >= len(p.s) || p.s[p.i] != ']' {
+		return attr, fmt.Errorf("unterminated attribute")
+	}
+	p.i++
+	return attr, nil
+}
+
+// ident parses an identifier of tags, ids, classes and attributes.
+func (p *parser) ident() string {
+	start := p.i
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if c != '-' && c != '_' && c != ':' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// space skips the spaces of the descendant combinator.
+func (p *parser) space() {
+	for p.i < len(p.s) && isSpace(p.s[p.i]) {
+		p.i++
+	}
+}
+
+// isSpace determines if the character is a space.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+// match determines if the element matches the compound selector.
+func (sel selector) match(node *html.Node) bool {
+	if node.Type != html.ElementNode || node.Data == "" {
+		return false
+	}
+	if sel.tag != "" && sel.tag != "*" && sel.tag != node.Data {
+		return false
+	}
+	attrs := make(map[string]string, len(node.Attr))
+	for _, attr := range node.Attr {
+		attrs[attr.Key] = attr.Val
+	}
+	if sel.id != "" && attrs["id"] != sel.id {
+		return false
+	}
+	classes := strings.Fields(attrs["class"])
+	for _, class := range sel.classes {
+		if !contains(classes, class) {
+			return false
+		}
+	}
+	for _, attr := range sel.attrs {
+		val, ok := attrs[attr.key]
+		if !ok || attr.hasVal && val != attr.val {
+			return false
+		}
+	}
+	return true
+}
+
+// matches determines if the element matches the last selector and its ancestors match the others in order.
+func matches(sels []selector, node *html.Node) bool {
+	last := len(sels) - 1
+	if !sels[last].match(node) {
+		return false
+	}
+	rest := sels[:last]
+	for parent := node.Parent; parent != nil && len(rest) > 0; parent = parent.Parent {
+		if rest[len(rest)-1].match(parent) {
+			rest = rest[:len(rest)-1]
+		}
+	}
+	return len(rest) == 0
+}
+
+// contains determines if the slice contains the string.
+func contains(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vuetest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     []selector
+	}{
+		{"li", []selector{{tag: "li"}}},
+		{"*", []selector{{tag: "*"}}},
+		{"#app", []selector{{id: "app"}}},
+		{"button.primary.large", []selector{{tag: "button", classes: []string{"primary", "large"}}}},
+		{`input[type="checkbox"][checked]`, []selector{{tag: "input", attrs: []attrSelector{
+			{key: "type", val: "checkbox", hasVal: true},
+			{key: "checked"},
+		}}}},
+		{"[data-id=1]", []selector{{attrs: []attrSelector{{key: "data-id", val: "1", hasVal: true}}}}},
+		{" ul  li.done ", []selector{{tag: "ul"}, {tag: "li", classes: []string{"done"}}}},
+	}
+	for _, test := range tests {
+		got, err := parseSelector(test.selector)
+		if err != nil {
+			t.Errorf("%s: %v", test.selector, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.selector, got, test.want)
+		}
+	}
+}
+
+func TestParseSelectorInvalid(t *testing.T) {
+	for _, selector := range []string{"", "  ", "li.", "#", "[", "[type", `[type="a]`, "li>a", "[=a]"} {
+		if _, err := parseSelector(selector); err == nil {
+			t.Errorf("%q: no error", selector)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+// Package vuetest mounts components headlessly to test their behavior under go test, without a browser:
+//
+//	w := vuetest.Mount(counter, vuetest.Data(&Data{Count: 1}))
+//	w.Trigger("click", "button.increment")
+//	if got := w.Find("span.count").Text(); got != "2" {
+//		t.Errorf("got %s", got)
+//	}
+//	w.SetData("Hidden", true)
+//	if w.Find("span.count").Exists() {
+//		t.Error("count is shown")
+//	}
+//
+// Methods render immediately, so the rendered elements are updated once triggers and data changes return.
+// Inputs bound by the model attribute are set by their value, e.g. w.Find("input.todo").SetValue("milk").
+// Events of components emitted by the bus are received by listeners of the test, e.g. bus.On(nil, listener).
+// Selectors are tags, ids, classes and attributes combined by descendants, e.g. ul li.done[data-id="1"].
+package vuetest
+
+import (
+	"fmt"
+	"github.com/norunners/vue"
+	"golang.org/x/net/html"
+	"strings"
+)
+
+// Option is an option of mounting.
+type Option func(*mount)
+
+// mount are the options of mounting.
+type mount struct {
+	data interface{}
+}
+
+// Data is the data of the mounted component, which replaces its data option,
+// e.g. to keep tests from sharing the data of the component.
+func Data(data interface{}) Option {
+	return func(m *mount) {
+		m.data = data
+	}
+}
+
+// Wrapper wraps a mounted component.
+type Wrapper struct {
+	vm *vue.ViewModel
+}
+
+// Mount mounts an instance of the component headlessly, then returns its wrapper.
+// The mounted hooks are called before returning.
+func Mount(comp *vue.Comp, options ...Option) *Wrapper {
+	m := &mount{}
+	for _, option := range options {
+		option(m)
+	}
+	return &Wrapper{vm: vue.Headless(comp, m.data)}
+}
+
+// VM returns the view model of the mounted component, e.g. to call methods or get data.
+func (w *Wrapper) VM() *vue.ViewModel {
+	return w.vm
+}
+
+// HTML returns the rendered html of the component.
+func (w *Wrapper) HTML() string {
+	return w.vm.HTML()
+}
+
+// SetData sets the data field of the dotted path to the value, then renders the component.
+func (w *Wrapper) SetData(field string, value interface{}) {
+	w.vm.Set(field, value)
+	w.vm.ForceUpdate()
+}
+
+// Trigger triggers the event of the type on the first element which matches the selector.
+// Panics if no element matches the selector.
+func (w *Wrapper) Trigger(typ, selector string) {
+	el := w.Find(selector)
+	if !el.Exists() {
+		panic(fmt.Errorf("no element matches the selector: %s", selector))
+	}
+	el.Trigger(typ)
+}
+
+// Find finds the first rendered element which matches the selector.
+// The element does not exist if no element matches.
+// Panics if the selector is invalid.
+func (w *Wrapper) Find(selector string) *Element {
+	all := w.find(selector, true)
+	if len(all) == 0 {
+		return &Element{w: w}
+	}
+	return all[0]
+}
+
+// FindAll finds the rendered elements which match the selector in document order.
+// Panics if the selector is invalid.
+func (w *Wrapper) FindAll(selector string) []*Element {
+	return w.find(selector, false)
+}
+
+// find finds the elements which match the selector, optionally only the first.
+func (w *Wrapper) find(selector string, first bool) []*Element {
+	sels, err := parseSelector(selector)
+	if err != nil {
+		panic(err)
+	}
+	var found []*Element
+	var walk func(node *html.Node) bool
+	walk = func(node *html.Node) bool {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+			if matches(sels, child) {
+				found = append(found, &Element{w: w, node: child})
+				if first {
+					return true
+				}
+			}
+			if walk(child) {
+				return true
+			}
+		}
+		return false
+	}
+	walk(w.vm.Node())
+	return found
+}
+
+// Unmount unmounts the component, which calls the unmounted hooks.
+func (w *Wrapper) Unmount() {
+	w.vm.Unmount()
+}
+
+// Element is a rendered element of a mounted component.
+// Elements are of the render they are found in, so they are found again after renders.
+type Element struct {
+	w    *Wrapper
+	node *html.Node
+}
+
+// Exists reports whether the element was found.
+func (el *Element) Exists() bool {
+	return el.node != nil
+}
+
+// Text returns the text content of the element.
+func (el *Element) Text() string {
+	var b strings.Builder
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	if el.node != nil {
+		walk(el.node)
+	}
+	return b.String()
+}
+
+// HTML returns the html of the element.
+func (el *Element) HTML() string {
+	if el.node == nil {
+		return ""
+	}
+	var b strings.Builder
+	if err := html.Render(&b, el.node); err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
+// Attr returns the value of the attribute of the element.
+func (el *Element) Attr(key string) (string, bool) {
+	if el.node == nil {
+		return "", false
+	}
+	for _, attr := range el.node.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// Trigger triggers the event of the type on the element, which calls the method bound by the on attribute,
+// e.g. Trigger("click") on <button v-on:click="Add">, then renders the component.
+// Panics if the element does not exist or has no method or field bound to the event.
+func (el *Element) Trigger(typ string) {
+	el.TriggerEvent(typ, vue.EventInit{})
+}
+
+// TriggerEvent triggers the event of the type with the payload on the element like Trigger,
+// e.g. TriggerEvent("keyup", vue.EventInit{Key: "Enter"}). Methods get the event from the context.
+func (el *Element) TriggerEvent(typ string, init vue.EventInit) {
+	if el.node == nil {
+		panic(fmt.Errorf("element does not exist to trigger: %s", typ))
+	}
+	el.w.vm.Dispatch(el.node, vue.NewEvent(typ, init))
+}
+
+// SetValue sets the value of the input element, which triggers the event of its model attribute,
+// e.g. SetValue("milk") on <input v-model="Todo">, then renders the component.
+func (el *Element) SetValue(value string) {
+	el.TriggerEvent(el.modelEvent(), vue.EventInit{Value: value})
+}
+
+// SetChecked sets the checked state of the checkbox, which triggers the event of its model attribute,
+// e.g. SetChecked(true) on <input type="checkbox" v-model="Done">, then renders the component.
+func (el *Element) SetChecked(checked bool) {
+	el.TriggerEvent(el.modelEvent(), vue.EventInit{Checked: checked})
+}
+
+// modelEvent returns the type of the event of the model attribute of the element.
+// Checkboxes and formatted inputs are updated on change, otherwise on input.
+func (el *Element) modelEvent() string {
+	if _, ok := el.Attr("change"); ok {
+		return "change"
+	}
+	return "input"
+}
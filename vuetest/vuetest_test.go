@@ -0,0 +1,168 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package vuetest_test
+
+import (
+	"github.com/norunners/vue"
+	"github.com/norunners/vue/vuetest"
+	"testing"
+)
+
+type data struct {
+	Count int
+	Todo  string
+	Done  bool
+	Key   string
+	Shown bool
+}
+
+func Increment(context vue.Context) {
+	context.Data().(*data).Count++
+}
+
+func Press(context vue.Context) {
+	context.Data().(*data).Key = context.Event().Key()
+}
+
+var comp = vue.Component(
+	vue.Template(`<div>
+		<span class="count" v-if="Shown">{{ Count }}</span>
+		<button class="increment" v-on:click="Increment">+</button>
+		<input class="todo" v-model="Todo" v-on:keyup="Press">
+		<input class="done" type="checkbox" v-model="Done">
+		<button class="unknown" v-on:click="Unknown">?</button>
+	</div>`),
+	vue.Data(&data{}),
+	vue.Methods(Increment, Press),
+)
+
+func TestMount(t *testing.T) {
+	w := vuetest.Mount(comp, vuetest.Data(&data{Count: 1, Shown: true}))
+	if got := w.Find("span.count").Text(); got != "1" {
+		t.Errorf("got %s, want 1", got)
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	d := &data{Count: 1, Shown: true}
+	w := vuetest.Mount(comp, vuetest.Data(d))
+	w.Trigger("click", "button.increment")
+	if d.Count != 2 {
+		t.Errorf("got count %d, want 2", d.Count)
+	}
+	if got := w.Find("span.count").Text(); got != "2" {
+		t.Errorf("got %s, want 2", got)
+	}
+}
+
+func TestTriggerEvent(t *testing.T) {
+	d := &data{}
+	w := vuetest.Mount(comp, vuetest.Data(d))
+	w.Find("input.todo").TriggerEvent("keyup", vue.EventInit{Key: "Enter"})
+	if d.Key != "Enter" {
+		t.Errorf("got key %q, want Enter", d.Key)
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	d := &data{}
+	w := vuetest.Mount(comp, vuetest.Data(d))
+	w.Find("input.todo").SetValue("milk")
+	if d.Todo != "milk" {
+		t.Errorf("got todo %q, want milk", d.Todo)
+	}
+	if got, _ := w.Find("input.todo").Attr("value"); got != "milk" {
+		t.Errorf("got value %q, want milk", got)
+	}
+}
+
+func TestSetChecked(t *testing.T) {
+	d := &data{}
+	w := vuetest.Mount(comp, vuetest.Data(d))
+	w.Find("input.done").SetChecked(true)
+	if !d.Done {
+		t.Error("not done")
+	}
+}
+
+func TestTriggerUnknownMethod(t *testing.T) {
+	w := vuetest.Mount(comp, vuetest.Data(&data{}))
+	defer func() {
+		if recover() == nil {
+			t.Error("unknown method did not panic")
+		}
+	}()
+	w.Trigger("click", "button.unknown")
+}
+
+func TestTriggerUnbound(t *testing.T) {
+	w := vuetest.Mount(comp, vuetest.Data(&data{}))
+	defer func() {
+		if recover() == nil {
+			t.Error("unbound event did not panic")
+		}
+	}()
+	w.Trigger("dblclick", "button.increment")
+}
+
+func TestFind(t *testing.T) {
+	w := vuetest.Mount(comp, vuetest.Data(&data{}))
+	if w.Find("span.missing").Exists() {
+		t.Error("missing element exists")
+	}
+	if got := len(w.FindAll("div button")); got != 2 {
+		t.Errorf("got %d buttons, want 2", got)
+	}
+	if !w.Find(`input[type="checkbox"]`).Exists() {
+		t.Error("checkbox does not exist")
+	}
+}
+
+func TestSetData(t *testing.T) {
+	w := vuetest.Mount(comp, vuetest.Data(&data{Shown: true}))
+	w.SetData("Shown", false)
+	if w.Find("span.count").Exists() {
+		t.Error("count is shown")
+	}
+}
+
+type post struct {
+	Body string
+}
+
+var page = vue.Component(
+	vue.Template(`<main><post-editor v-model:Body="Body"></post-editor></main>`),
+	vue.Data(&post{}),
+	vue.Sub("post-editor", vue.Component(
+		vue.Template(`<div><rich-text v-model:Body="Body"></rich-text></div>`),
+		vue.Props("Body"),
+		vue.Sub("rich-text", vue.RichText("Body")),
+	)),
+)
+
+func TestRichTextModel(t *testing.T) {
+	p := &post{}
+	w := vuetest.Mount(page, vuetest.Data(p))
+	w.Find(".rich-text-content").SetValue(`<b>bold</b><script>alert(1)</script>`)
+	if p.Body != "<b>bold</b>" {
+		t.Errorf("got body %q, want <b>bold</b>", p.Body)
+	}
+	if got := w.Find(".rich-text-content b").Text(); got != "bold" {
+		t.Errorf("got text %q, want bold", got)
+	}
+}
+
+func TestTextEscaping(t *testing.T) {
+	text := vue.Component(
+		vue.Template(`<p class="todo">{{ Todo }}</p>`),
+		vue.Data(&data{Todo: `Tom & "Jerry" <3`}),
+	)
+	w := vuetest.Mount(text)
+	if got, want := w.Find("p.todo").Text(), `Tom & "Jerry" <3`; got != want {
+		t.Errorf("got text %s, want %s", got, want)
+	}
+	if got, want := w.HTML(), `<p class="todo">Tom &amp; &#34;Jerry&#34; &lt;3</p>`; got != want {
+		t.Errorf("got html %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,23 @@
+package vue
+
+// wakeLockedField is the data field of the wake lock state.
+const wakeLockedField = "WakeLocked"
+
+// WakeLock is the wake lock option for root components.
+// The wake lock state is mapped to the WakeLocked data field unless data has the same field.
+// The component renders when the wake lock is acquired or released.
+func WakeLock() Option {
+	return func(comp *Comp) {
+		comp.wakeLock = true
+	}
+}
+
+// wakeLockData maps the wake lock state to data.
+func (vm *ViewModel) wakeLockData() {
+	if !vm.comp.wakeLock {
+		return
+	}
+	if _, ok := vm.data[wakeLockedField]; !ok {
+		vm.data[wakeLockedField] = wakeLocked()
+	}
+}
@@ -0,0 +1,66 @@
+//go:build js && wasm
+// +build js,wasm
+
+package vue
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// wakeLock is the screen wake lock shared by components.
+var wakeLock struct {
+	sentinel js.Value
+	locked   bool
+	renders  []func()
+}
+
+// RequestWakeLock requests a screen wake lock which keeps the screen on, e.g. for dashboards or kiosks.
+// The browser releases the wake lock when the page is hidden.
+// RequestWakeLock blocks until the wake lock is acquired, so it must be called from a goroutine.
+func RequestWakeLock() error {
+	api := js.Global().Get("navigator").Get("wakeLock")
+	if api == js.Undefined() {
+		return fmt.Errorf("wake lock is not supported")
+	}
+	sentinel, err := await(api.Call("request", "screen"))
+	if err != nil {
+		return err
+	}
+
+	wakeLock.sentinel = sentinel
+	wakeLock.locked = true
+	listen(sentinel, "release", func(js.Value) {
+		wakeLock.locked = false
+		renderWakeLock()
+	})
+	renderWakeLock()
+	return nil
+}
+
+// ReleaseWakeLock releases the screen wake lock.
+func ReleaseWakeLock() {
+	if wakeLock.locked {
+		wakeLock.sentinel.Call("release")
+	}
+}
+
+// watchWakeLock watches the wake lock state.
+func (vm *ViewModel) watchWakeLock() {
+	if !vm.comp.wakeLock || vm.comp.isSub {
+		return
+	}
+	wakeLock.renders = append(wakeLock.renders, vm.render)
+}
+
+// renderWakeLock renders the components which watch the wake lock.
+func renderWakeLock() {
+	for _, render := range wakeLock.renders {
+		render()
+	}
+}
+
+// wakeLocked determines if the wake lock is acquired.
+func wakeLocked() bool {
+	return wakeLock.locked
+}
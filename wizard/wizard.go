@@ -0,0 +1,158 @@
+// Package wizard manages multi-step forms with per-step validation.
+package wizard
+
+import (
+	"fmt"
+	"github.com/norunners/vue"
+	"github.com/norunners/vue/validate"
+	"html"
+	"strings"
+)
+
+// Step is a step of a wizard.
+// The data of the step is validated by its validate tags and checks before leaving the step forward.
+type Step struct {
+	Name   string
+	Data   interface{}
+	Checks []validate.Check
+}
+
+// Wizard is the state of a multi-step form.
+type Wizard struct {
+	steps   []Step
+	current int
+	errs    validate.Errors
+	guard   func(from, to int) bool
+}
+
+// New creates a new wizard from the steps.
+func New(steps ...Step) *Wizard {
+	if len(steps) == 0 {
+		panic(fmt.Errorf("wizard requires steps"))
+	}
+	return &Wizard{steps: steps, errs: make(validate.Errors)}
+}
+
+// Guard guards navigation between steps, e.g. to confirm leaving unsaved changes.
+// Navigation is canceled when the guard returns false.
+func (w *Wizard) Guard(guard func(from, to int) bool) {
+	w.guard = guard
+}
+
+// Current returns the index of the current step.
+func (w *Wizard) Current() int {
+	return w.current
+}
+
+// Step returns the current step.
+func (w *Wizard) Step() Step {
+	return w.steps[w.current]
+}
+
+// Names returns the names of the steps.
+func (w *Wizard) Names() []string {
+	names := make([]string, len(w.steps))
+	for i, step := range w.steps {
+		names[i] = step.Name
+	}
+	return names
+}
+
+// Errors returns the validation errors of the current step.
+func (w *Wizard) Errors() validate.Errors {
+	return w.errs
+}
+
+// First determines if the current step is the first step.
+func (w *Wizard) First() bool {
+	return w.current == 0
+}
+
+// Last determines if the current step is the last step.
+func (w *Wizard) Last() bool {
+	return w.current == len(w.steps)-1
+}
+
+// Next validates the current step then moves to the next step.
+// Returns false if the step is invalid, guarded or the last step.
+func (w *Wizard) Next() bool {
+	if w.Last() || !w.validate(w.current) {
+		return false
+	}
+	return w.move(w.current + 1)
+}
+
+// Prev moves to the previous step without validation.
+// Returns false if the step is guarded or the first step.
+func (w *Wizard) Prev() bool {
+	if w.First() {
+		return false
+	}
+	return w.move(w.current - 1)
+}
+
+// Submit validates all steps then aggregates the data of steps by name.
+// The wizard moves to the first invalid step and returns false if a step is invalid.
+func (w *Wizard) Submit() (map[string]interface{}, bool) {
+	for i := range w.steps {
+		if !w.validate(i) {
+			w.current = i
+			return nil, false
+		}
+	}
+
+	data := make(map[string]interface{}, len(w.steps))
+	for _, step := range w.steps {
+		data[step.Name] = step.Data
+	}
+	return data, true
+}
+
+// validate validates the step and keeps its errors.
+// Invalid tags of the data are errors of the form, so the step is invalid.
+func (w *Wizard) validate(i int) bool {
+	step := w.steps[i]
+	errs, err := validate.Struct(step.Data, step.Checks...)
+	if err != nil {
+		errs = make(validate.Errors)
+		errs.Add(validate.Form, err.Error())
+	}
+	w.errs = errs
+	return w.errs.Valid()
+}
+
+// move moves to the step unless guarded.
+func (w *Wizard) move(to int) bool {
+	if w.guard != nil && !w.guard(w.current, to) {
+		return false
+	}
+	w.current = to
+	w.errs = make(validate.Errors)
+	return true
+}
+
+// Stepper creates a functional component which renders the steps as an ordered list.
+// The props are Steps, the names of steps, and Current, the index of the current step,
+// e.g. <wizard-stepper v-bind:steps="Steps" v-bind:current="Current">.
+// Steps are classed as complete, current or upcoming.
+func Stepper() *vue.Comp {
+	return vue.Functional(func(props map[string]interface{}) string {
+		steps, _ := props["Steps"].([]string)
+		current, _ := props["Current"].(int)
+
+		var b strings.Builder
+		b.WriteString(`<ol class="wizard-stepper">`)
+		for i, step := range steps {
+			class := "upcoming"
+			switch {
+			case i < current:
+				class = "complete"
+			case i == current:
+				class = "current"
+			}
+			fmt.Fprintf(&b, `<li class="%s">%s</li>`, class, html.EscapeString(step))
+		}
+		b.WriteString(`</ol>`)
+		return b.String()
+	}, "Steps", "Current")
+}